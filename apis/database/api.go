@@ -3,11 +3,12 @@ package database
 import (
 	// Stdlib
 	"encoding/json"
+	"time"
 
 	// RPC
-
 	"github.com/goscorum/scorumgo/interfaces"
 	"github.com/goscorum/scorumgo/internal/call"
+	"github.com/goscorum/scorumgo/types"
 
 	// Vendor
 	"github.com/pkg/errors"
@@ -18,6 +19,9 @@ const (
 	NumbericAPIID = 0
 )
 
+// LookupAccountsMaxLimit is the maximum number of names lookup_accounts returns.
+const LookupAccountsMaxLimit = 1000
+
 type API struct {
 	caller interfaces.Caller
 }
@@ -52,57 +56,113 @@ func (api *API) GetTrendingTagsRaw(afterTag string, limit uint32) (*json.RawMess
 	return call.Raw(api.caller, "get_trending_tags", []interface{}{afterTag, limit})
 }
 
-type DiscussionQuery struct {
-	Tag   string `json:"tag"`
-	Limit uint32 `json:"limit"`
-	// XXX: Not sure about the type here.
-	FilterTags     []string `json:"filter_tags"`
-	StartAuthor    string   `json:"start_author,omitempty"`
-	StartPermlink  string   `json:"start_permlink,omitempty"`
-	ParentAuthor   string   `json:"parent_author,omitempty"`
-	ParentPermlink string   `json:"parent_permlink"`
+func (api *API) getDiscussionsRaw(method string, query *DiscussionQuery) (*json.RawMessage, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	return call.Raw(api.caller, method, []interface{}{query})
+}
+
+func (api *API) getDiscussions(method string, query *DiscussionQuery) ([]*Content, error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+
+	var resp []*Content
+	if err := api.caller.Call(method, []interface{}{query}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (api *API) GetDiscussionsByTrendingRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_trending", query)
+	return api.getDiscussionsRaw("get_discussions_by_trending", query)
+}
+
+func (api *API) GetDiscussionsByTrending(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_trending", query)
 }
 
 func (api *API) GetDiscussionsByCreatedRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_created", query)
+	return api.getDiscussionsRaw("get_discussions_by_created", query)
+}
+
+func (api *API) GetDiscussionsByCreated(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_created", query)
 }
 
 func (api *API) GetDiscussionsByActiveRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_active", query)
+	return api.getDiscussionsRaw("get_discussions_by_active", query)
+}
+
+func (api *API) GetDiscussionsByActive(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_active", query)
 }
 
 func (api *API) GetDiscussionsByCashoutRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_cashout", query)
+	return api.getDiscussionsRaw("get_discussions_by_cashout", query)
+}
+
+func (api *API) GetDiscussionsByCashout(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_cashout", query)
 }
 
 func (api *API) GetDiscussionsByPayoutRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_payout", query)
+	return api.getDiscussionsRaw("get_discussions_by_payout", query)
+}
+
+func (api *API) GetDiscussionsByPayout(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_payout", query)
 }
 
 func (api *API) GetDiscussionsByVotesRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_votes", query)
+	return api.getDiscussionsRaw("get_discussions_by_votes", query)
+}
+
+func (api *API) GetDiscussionsByVotes(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_votes", query)
 }
 
 func (api *API) GetDiscussionsByChildrenRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_children", query)
+	return api.getDiscussionsRaw("get_discussions_by_children", query)
+}
+
+func (api *API) GetDiscussionsByChildren(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_children", query)
 }
 
 func (api *API) GetDiscussionsByHotRaw(query *DiscussionQuery) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_hot", query)
+	return api.getDiscussionsRaw("get_discussions_by_hot", query)
+}
+
+func (api *API) GetDiscussionsByHot(query *DiscussionQuery) ([]*Content, error) {
+	return api.getDiscussions("get_discussions_by_hot", query)
 }
 
 func (api *API) GetRecommendedForRaw(user string, limit uint32) (*json.RawMessage, error) {
-	return call.Raw(api.caller, "get_discussions_by_votes", []interface{}{user, limit})
+	if limit > DiscussionQueryMaxLimit {
+		return nil, errors.Errorf("GetRecommendedFor: limit must not exceed %v", DiscussionQueryMaxLimit)
+	}
+	return call.Raw(api.caller, "get_recommended_for", []interface{}{user, limit})
+}
+
+func (api *API) GetRecommendedFor(user string, limit uint32) ([]*Content, error) {
+	if limit > DiscussionQueryMaxLimit {
+		return nil, errors.Errorf("GetRecommendedFor: limit must not exceed %v", DiscussionQueryMaxLimit)
+	}
+
+	var resp []*Content
+	if err := api.caller.Call("get_recommended_for", []interface{}{user, limit}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 /*
    // Blocks and transactions
    (get_block_header)
    (get_block)
+   (get_ops_in_block)
    (get_state)
    (get_trending_categories)
    (get_best_categories)
@@ -114,6 +174,15 @@ func (api *API) GetBlockHeaderRaw(blockNum uint32) (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_block_header", []uint32{blockNum})
 }
 
+func (api *API) GetBlockHeader(blockNum uint32) (*BlockHeader, error) {
+	var resp BlockHeader
+	if err := api.caller.Call("get_block_header", []uint32{blockNum}, &resp); err != nil {
+		return nil, err
+	}
+	resp.Number = blockNum
+	return &resp, nil
+}
+
 func (api *API) GetBlockRaw(blockNum uint32) (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_block", []uint32{blockNum})
 }
@@ -127,10 +196,45 @@ func (api *API) GetBlock(blockNum uint32) (*Block, error) {
 	return &resp, nil
 }
 
+func (api *API) GetOpsInBlockRaw(blockNum uint32, onlyVirtual bool) (*json.RawMessage, error) {
+	return call.Raw(api.caller, "get_ops_in_block", []interface{}{blockNum, onlyVirtual})
+}
+
+// GetOpsInBlock returns the operations applied in the given block, in order.
+// Unlike GetBlock, the result includes virtual operations,
+// onlyVirtual can be used to get virtual operations only.
+func (api *API) GetOpsInBlock(blockNum uint32, onlyVirtual bool) ([]*AppliedOperation, error) {
+	raw, err := api.GetOpsInBlockRaw(blockNum, onlyVirtual)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*AppliedOperation
+	if err := json.Unmarshal(*raw, &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: database_api: failed to unmarshal get_ops_in_block response")
+	}
+	return resp, nil
+}
+
 func (api *API) GetStateRaw(path string) (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_state", []string{path})
 }
 
+func (api *API) GetState(path string) (*State, error) {
+	raw, err := api.GetStateRaw(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp State
+	if err := json.Unmarshal(*raw, &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: database_api: failed to unmarshal get_state response")
+	}
+	return &resp, nil
+}
+
 func (api *API) GetTrendingCategoriesRaw(after string, limit uint32) (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_trending_categories", []interface{}{after, limit})
 }
@@ -199,6 +303,14 @@ func (api *API) GetWitnessScheduleRaw() (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_witness_schedule", call.EmptyParams)
 }
 
+func (api *API) GetWitnessSchedule() (*WitnessSchedule, error) {
+	var resp WitnessSchedule
+	if err := api.caller.Call("get_witness_schedule", call.EmptyParams, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (api *API) GetHardforkVersionRaw() (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_hardfork_version", call.EmptyParams)
 }
@@ -265,9 +377,26 @@ func (api *API) LookupAccountNamesRaw(accountNames []string) (*json.RawMessage,
 }
 
 func (api *API) LookupAccountsRaw(lowerBoundName string, limit uint32) (*json.RawMessage, error) {
+	if limit > LookupAccountsMaxLimit {
+		return nil, errors.Errorf("LookupAccounts: limit must not exceed %v", LookupAccountsMaxLimit)
+	}
 	return call.Raw(api.caller, "lookup_accounts", []interface{}{lowerBoundName, limit})
 }
 
+func (api *API) LookupAccounts(lowerBoundName string, limit uint32) ([]string, error) {
+	raw, err := api.LookupAccountsRaw(lowerBoundName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []string
+	if err := json.Unmarshal(*raw, &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: database_api: failed to unmarshal lookup_accounts response")
+	}
+	return resp, nil
+}
+
 func (api *API) GetAccountCountRaw() (*json.RawMessage, error) {
 	return call.Raw(api.caller, "get_account_count", call.EmptyParams)
 }
@@ -280,6 +409,20 @@ func (api *API) GetAccountHistoryRaw(account string, from uint64, limit uint32)
 	return call.Raw(api.caller, "get_account_history", []interface{}{account, from, limit})
 }
 
+func (api *API) GetAccountHistory(account string, from uint64, limit uint32) ([]*AccountHistoryEntry, error) {
+	raw, err := api.GetAccountHistoryRaw(account, from, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*AccountHistoryEntry
+	if err := json.Unmarshal(*raw, &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: database_api: failed to unmarshal get_account_history response")
+	}
+	return resp, nil
+}
+
 /*
    // Market
    (get_order_book)
@@ -302,6 +445,20 @@ func (api *API) GetOrderBookRaw(limit uint32) (*json.RawMessage, error) {
    (verify_account_authority)
 */
 
+func (api *API) GetTransactionHexRaw(tx *types.Transaction) (*json.RawMessage, error) {
+	return call.Raw(api.caller, "get_transaction_hex", []interface{}{tx})
+}
+
+// GetTransactionHex returns the transaction serialized by the node as hex,
+// it can be decoded using types.DecodeTransactionHex.
+func (api *API) GetTransactionHex(tx *types.Transaction) (string, error) {
+	var resp string
+	if err := api.caller.Call("get_transaction_hex", []interface{}{tx}, &resp); err != nil {
+		return "", err
+	}
+	return resp, nil
+}
+
 /*
    // Votes
    (get_active_votes)
@@ -328,7 +485,7 @@ func (api *API) GetAccountVotesRaw(voter string) (*json.RawMessage, error) {
    // Content
    (get_content)
    (get_content_replies)
-   (get_discussions_by_author_before_date) - MISSING
+   (get_discussions_by_author_before_date)
    (get_replies_by_last_update)
 */
 
@@ -357,6 +514,57 @@ func (api *API) GetContentReplies(parentAuthor, parentPermlink string) ([]*Conte
 	return resp, nil
 }
 
+func (api *API) GetDiscussionsByAuthorBeforeDateRaw(
+	author string,
+	startPermlink string,
+	beforeDate time.Time,
+	limit uint32,
+) (*json.RawMessage, error) {
+
+	params, err := discussionsByAuthorBeforeDateParams(author, startPermlink, beforeDate, limit)
+	if err != nil {
+		return nil, err
+	}
+	return call.Raw(api.caller, "get_discussions_by_author_before_date", params)
+}
+
+func (api *API) GetDiscussionsByAuthorBeforeDate(
+	author string,
+	startPermlink string,
+	beforeDate time.Time,
+	limit uint32,
+) ([]*Content, error) {
+
+	params, err := discussionsByAuthorBeforeDateParams(author, startPermlink, beforeDate, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*Content
+	if err := api.caller.Call("get_discussions_by_author_before_date", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// discussionsByAuthorBeforeDateParams checks the limit and builds the call params.
+// The node expects the date in UTC, types.Time does not convert it.
+func discussionsByAuthorBeforeDateParams(
+	author string,
+	startPermlink string,
+	beforeDate time.Time,
+	limit uint32,
+) ([]interface{}, error) {
+
+	if limit > DiscussionQueryMaxLimit {
+		return nil, errors.Errorf(
+			"GetDiscussionsByAuthorBeforeDate: limit must not exceed %v", DiscussionQueryMaxLimit)
+	}
+
+	utc := beforeDate.UTC()
+	return []interface{}{author, startPermlink, &types.Time{Time: &utc}, limit}, nil
+}
+
 func (api *API) GetRepliesByLastUpdateRaw(
 	startAuthor string,
 	startPermlink string,
@@ -367,6 +575,20 @@ func (api *API) GetRepliesByLastUpdateRaw(
 		api.caller, "get_replies_by_last_update", []interface{}{startAuthor, startPermlink, limit})
 }
 
+func (api *API) GetRepliesByLastUpdate(
+	startAuthor string,
+	startPermlink string,
+	limit uint32,
+) ([]*Content, error) {
+
+	var resp []*Content
+	params := []interface{}{startAuthor, startPermlink, limit}
+	if err := api.caller.Call("get_replies_by_last_update", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 /*
    // Witnesses
    (get_witnesses)
@@ -0,0 +1,71 @@
+package database
+
+import (
+	// Stdlib
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// callerFunc adapts a function to interfaces.Caller. The value returned
+// by the function is passed to the response through JSON, like a node response.
+type callerFunc func(method string, params interface{}) (interface{}, error)
+
+func (f callerFunc) Call(method string, params, response interface{}) error {
+	result, err := f(method, params)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+func TestAPI_GetDiscussions_NilQuery(t *testing.T) {
+	api := NewAPI(callerFunc(func(method string, params interface{}) (interface{}, error) {
+		t.Errorf("unexpected call: %v", method)
+		return nil, nil
+	}))
+
+	if _, err := api.GetDiscussionsByTrending(nil); err == nil {
+		t.Error("expected an error")
+	}
+	if _, err := api.GetDiscussionsByCreatedRaw(nil); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestAPI_GetDiscussionsByAuthorBeforeDate(t *testing.T) {
+	api := NewAPI(callerFunc(func(method string, params interface{}) (interface{}, error) {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, err
+		}
+		expected := `["alice","hello","2018-03-02T14:13:20",10]`
+		if method != "get_discussions_by_author_before_date" || string(data) != expected {
+			t.Errorf("unexpected call: %v %v", method, string(data))
+		}
+		return []map[string]interface{}{{"author": "alice", "permlink": "hello"}}, nil
+	}))
+
+	// The same instant in UTC+3.
+	beforeDate := time.Date(2018, 3, 2, 17, 13, 20, 0, time.FixedZone("MSK", 3*60*60))
+
+	resp, err := api.GetDiscussionsByAuthorBeforeDate("alice", "hello", beforeDate, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp) != 1 || resp[0].Author != "alice" {
+		t.Errorf("unexpected response: %v", resp)
+	}
+	if _, err := api.GetDiscussionsByAuthorBeforeDateRaw("alice", "hello", beforeDate, 10); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := api.GetDiscussionsByAuthorBeforeDate("alice", "hello", beforeDate, DiscussionQueryMaxLimit+1); err == nil {
+		t.Error("expected an error")
+	}
+}
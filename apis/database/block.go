@@ -0,0 +1,95 @@
+package database
+
+import (
+	// Stdlib
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// NumFromID returns the block number encoded in the given block ID,
+// i.e. the first 4 bytes of the ID in the big-endian byte order.
+func NumFromID(blockID string) (uint32, error) {
+	raw, err := hex.DecodeString(blockID)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to decode block ID: %v", blockID)
+	}
+	if len(raw) < 4 {
+		return 0, errors.Errorf("invalid block ID: %v", blockID)
+	}
+	return binary.BigEndian.Uint32(raw[:4]), nil
+}
+
+// ID computes the block ID from the block header,
+// so that it can be verified or used even when the node is not sending it.
+//
+// The ID is the first 20 bytes of sha224 of the signed block header
+// with the first 4 bytes replaced by the block number.
+func (block *Block) ID() (string, error) {
+	var b bytes.Buffer
+	encoder := transaction.NewEncoder(&b)
+	if err := block.encodeHeader(encoder); err != nil {
+		return "", err
+	}
+
+	signature, err := hex.DecodeString(block.WitnessSignature)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to decode witness signature: %v", block.WitnessSignature)
+	}
+	if err := encoder.EncodeRaw(signature); err != nil {
+		return "", err
+	}
+
+	prevNum, err := NumFromID(block.Previous)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum224(b.Bytes())
+	binary.BigEndian.PutUint32(digest[:4], prevNum+1)
+	return hex.EncodeToString(digest[:20]), nil
+}
+
+// Header returns the header of the block.
+func (block *Block) Header() *BlockHeader {
+	return &BlockHeader{
+		Number:                block.Number,
+		Previous:              block.Previous,
+		Timestamp:             block.Timestamp,
+		Witness:               block.Witness,
+		TransactionMerkleRoot: block.TransactionMerkleRoot,
+		Extensions:            block.Extensions,
+	}
+}
+
+// encodeHeader serializes the block header, the witness signature not included.
+func (block *Block) encodeHeader(encoder *transaction.Encoder) error {
+	if block.Timestamp == nil || block.Timestamp.Time == nil {
+		return errors.New("block timestamp not set")
+	}
+
+	previous, err := hex.DecodeString(block.Previous)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode previous block ID: %v", block.Previous)
+	}
+	merkleRoot, err := hex.DecodeString(block.TransactionMerkleRoot)
+	if err != nil {
+		return errors.Wrapf(err,
+			"failed to decode transaction merkle root: %v", block.TransactionMerkleRoot)
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeRaw(previous)
+	enc.Encode(block.Timestamp)
+	enc.Encode(block.Witness)
+	enc.EncodeRaw(merkleRoot)
+	enc.Encode(block.Extensions)
+	return enc.Err()
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+	"github.com/goscorum/scorumgo/types"
+)
+
+// The first block of the Steem blockchain.
+const block1JSON = `{
+	"previous": "0000000000000000000000000000000000000000",
+	"timestamp": "2016-03-24T16:05:00",
+	"witness": "initminer",
+	"transaction_merkle_root": "0000000000000000000000000000000000000000",
+	"extensions": [],
+	"witness_signature": "204f8ad56a8f5cf722a02b035a61b500aa59b9519b2c33c77a80c0a714680a5a5a7a340d909d19996613c5e4ae92146b9add8a7a663eef37d837ef881477313043",
+	"transactions": []
+}`
+
+func TestBlock_ID(t *testing.T) {
+	expected := "0000000109833ce528d5bbfb3f6225b39ee10086"
+
+	var block Block
+	if err := json.Unmarshal([]byte(block1JSON), &block); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := block.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	num, err := NumFromID(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if num != 1 {
+		t.Errorf("expected block number 1, got %v", num)
+	}
+}
+
+func TestBlock_encodeHeader_Extensions(t *testing.T) {
+	var block Block
+	if err := json.Unmarshal([]byte(block1JSON), &block); err != nil {
+		t.Fatal(err)
+	}
+	encode := func() string {
+		var b bytes.Buffer
+		if err := block.encodeHeader(transaction.NewEncoder(&b)); err != nil {
+			t.Fatal(err)
+		}
+		return hex.EncodeToString(b.Bytes())
+	}
+	header := encode()
+
+	// The extensions are the last field of the header, the empty list is 00.
+	data := strings.Replace(block1JSON, `"extensions": []`, `"extensions": [[1,"0.19.2"]]`, 1)
+	if err := json.Unmarshal([]byte(data), &block); err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.TrimSuffix(header, "00") + "01" + "0102001300"
+	if got := encode(); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if _, err := block.ID(); err != nil {
+		t.Error(err)
+	}
+	if block.Header().Extensions[0].(*types.Version).String() != "0.19.2" {
+		t.Errorf("unexpected header extensions: %v", block.Header().Extensions)
+	}
+}
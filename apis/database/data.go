@@ -2,39 +2,42 @@ package database
 
 import (
 	// Stdlib
-
 	"encoding/json"
+	"reflect"
 	"strconv"
 	"strings"
 
 	// RPC
 	"github.com/goscorum/scorumgo/types"
+
+	// Vendor
+	"github.com/pkg/errors"
 )
 
 type DynamicGlobalProperties struct {
-	ID                       uint32       `json:"id"`
-	Time                     types.Time   `json:"time"`
-	HeadBlockNumber          types.UInt32 `json:"head_block_number"`
-	HeadBlockID              string       `json:"head_block_id"`
-	CurrentWitness           string       `json:"current_witness"`
-	TotalSupply              string       `json:"total_supply"`
-	AccountsCurrentSupply    string       `json:"accounts_current_supply"`
-	ConfidentialSupply       string       `json:"confidential_supply"`
-	TotalVestingFundScorum   string       `json:"total_vesting_fund_scorum"`
-	TotalVestingShares       string       `json:"total_vesting_shares"`
-	TotalRewardShares2       string       `json:"total_reward_shares2"`
-	MaximumBlockSize         int32        `json:"maximum_block_size"`
-	CurrentAslot             int32        `json:"current_aslot"`
-	RecentSlotsFilled        string       `json:"recent_slots_filled"`
-	ParticipationCount       int32        `json:"participation_count"`
-	LastIrreversibleBlockNum uint32       `json:"last_irreversible_block_num"`
-	VotePowerReserveRate     int32        `json:"vote_power_reserve_rate"`
-	InviteQuorum             int32        `json:"invite_quorum"`
-	DropoutQuorum            int32        `json:"dropout_quorum"`
-	ChangeQuorum             int32        `json:"change_quorum"`
-	CurrentReserveRatio      int32        `json:"current_reserve_ratio"`
-	AverageBlockSize         int32        `json:"average_block_size"`
-	MaxVirtualBandwidth      string       `json:"max_virtual_bandwidth"`
+	ID                       uint32        `json:"id"`
+	Time                     types.Time    `json:"time"`
+	HeadBlockNumber          types.UInt32  `json:"head_block_number"`
+	HeadBlockID              string        `json:"head_block_id"`
+	CurrentWitness           string        `json:"current_witness"`
+	TotalSupply              *types.Asset  `json:"total_supply"`
+	AccountsCurrentSupply    *types.Asset  `json:"accounts_current_supply"`
+	ConfidentialSupply       *types.Asset  `json:"confidential_supply"`
+	TotalVestingFundScorum   *types.Asset  `json:"total_vesting_fund_scorum"`
+	TotalVestingShares       *types.Asset  `json:"total_vesting_shares"`
+	TotalRewardShares2       *types.BigInt `json:"total_reward_shares2"`
+	MaximumBlockSize         int32         `json:"maximum_block_size"`
+	CurrentAslot             int32         `json:"current_aslot"`
+	RecentSlotsFilled        *types.BigInt `json:"recent_slots_filled"`
+	ParticipationCount       int32         `json:"participation_count"`
+	LastIrreversibleBlockNum uint32        `json:"last_irreversible_block_num"`
+	VotePowerReserveRate     int32         `json:"vote_power_reserve_rate"`
+	InviteQuorum             int32         `json:"invite_quorum"`
+	DropoutQuorum            int32         `json:"dropout_quorum"`
+	ChangeQuorum             int32         `json:"change_quorum"`
+	CurrentReserveRatio      int32         `json:"current_reserve_ratio"`
+	AverageBlockSize         int32         `json:"average_block_size"`
+	MaxVirtualBandwidth      *types.BigInt `json:"max_virtual_bandwidth"`
 }
 
 type Config struct {
@@ -150,13 +153,32 @@ type Config struct {
 	ScorumAtomicswapSecretMaxLength                     int32  `json:"SCORUM_ATOMICSWAP_SECRET_MAX_LENGTH"`
 }
 
+// ParsePublicKey parses a public key string using the address prefix of the node.
+func (config *Config) ParsePublicKey(s string) (*types.PublicKey, error) {
+	return types.ParsePublicKey(s, config.ScorumAddressPrefix)
+}
+
+// WitnessSchedule is the witness schedule as returned by get_witness_schedule.
+//
+// CurrentShuffledWitnesses is the production order of the current round.
+// The schedule is shuffled again once NextShuffleBlockNum is reached.
+type WitnessSchedule struct {
+	ID                       uint32                 `json:"id"`
+	CurrentVirtualTime       string                 `json:"current_virtual_time"`
+	NextShuffleBlockNum      uint32                 `json:"next_shuffle_block_num"`
+	CurrentShuffledWitnesses []string               `json:"current_shuffled_witnesses"`
+	NumScheduledWitnesses    uint32                 `json:"num_scheduled_witnesses"`
+	MedianProps              *types.ChainProperties `json:"median_props"`
+	MajorityVersion          string                 `json:"majority_version"`
+}
+
 type Account struct {
 	ID                        uint32            `json:"id"`
 	Name                      string            `json:"name"`
 	Owner                     types.Authority   `json:"owner"`
 	Active                    types.Authority   `json:"active"`
 	Posting                   types.Authority   `json:"posting"`
-	MemoKey                   string            `json:"memo_key"`
+	MemoKey                   *types.PublicKey  `json:"memo_key"`
 	JsonMetadata              string            `json:"json_metadata"`
 	Proxy                     string            `json:"proxy"`
 	LastOwnerUpdate           types.Time        `json:"last_owner_update"`
@@ -175,11 +197,11 @@ type Account struct {
 	CanVote                   bool              `json:"can_vote"`
 	VotingPower               int32             `json:"voting_power"`
 	LastVoteTime              types.Time        `json:"last_vote_time"`
-	Balance                   string            `json:"balance"`
-	VestingShares             string            `json:"vesting_shares"`
-	DelegatedVestingShares    string            `json:"delegated_vesting_shares"`
-	ReceivedVestingShares     string            `json:"received_vesting_shares"`
-	VestingWithdrawRate       string            `json:"vesting_withdraw_rate"`
+	Balance                   *types.Asset      `json:"balance"`
+	VestingShares             *types.Asset      `json:"vesting_shares"`
+	DelegatedVestingShares    *types.Asset      `json:"delegated_vesting_shares"`
+	ReceivedVestingShares     *types.Asset      `json:"received_vesting_shares"`
+	VestingWithdrawRate       *types.Asset      `json:"vesting_withdraw_rate"`
 	NextVestingWithdrawal     types.Time        `json:"next_vesting_withdrawal"`
 	CurationRewards           string            `json:"curation_rewards"`
 	PostingRewards            string            `json:"posting_rewards"`
@@ -193,7 +215,7 @@ type Account struct {
 	LastMarketBandwidthUpdate types.Time        `json:"last_market_bandwidth_update"`
 	LastPost                  types.Time        `json:"last_post"`
 	LastRootPost              types.Time        `json:"last_root_post"`
-	VestingBalance            string            `json:"vesting_balance"`
+	VestingBalance            *types.Asset      `json:"vesting_balance"`
 	TransferHistory           []json.RawMessage `json:"transfer_history"`
 	PostHistory               []json.RawMessage `json:"post_history"`
 	VoteHistory               []json.RawMessage `json:"vote_history"`
@@ -203,15 +225,98 @@ type Account struct {
 	GuestBloggers             []json.RawMessage `json:"guest_bloggers"`
 }
 
+// BlockHeader is the block header as returned by get_block_header,
+// i.e. a block without the transactions and the witness signature.
+type BlockHeader struct {
+	Number                uint32                      `json:"-"`
+	Previous              string                      `json:"previous"`
+	Timestamp             *types.Time                 `json:"timestamp"`
+	Witness               string                      `json:"witness"`
+	TransactionMerkleRoot string                      `json:"transaction_merkle_root"`
+	Extensions            types.BlockHeaderExtensions `json:"extensions"`
+}
+
 type Block struct {
-	Number                uint32               `json:"-"`
-	Timestamp             *types.Time          `json:"timestamp"`
-	Witness               string               `json:"witness"`
-	WitnessSignature      string               `json:"witness_signature"`
-	TransactionMerkleRoot string               `json:"transaction_merkle_root"`
-	Previous              string               `json:"previous"`
-	Extensions            [][]interface{}      `json:"extensions"`
-	Transactions          []*types.Transaction `json:"transactions"`
+	Number                uint32                      `json:"-"`
+	BlockID               string                      `json:"block_id"`
+	Timestamp             *types.Time                 `json:"timestamp"`
+	Witness               string                      `json:"witness"`
+	WitnessSignature      string                      `json:"witness_signature"`
+	SigningKey            *types.PublicKey            `json:"signing_key"`
+	TransactionMerkleRoot string                      `json:"transaction_merkle_root"`
+	Previous              string                      `json:"previous"`
+	Extensions            types.BlockHeaderExtensions `json:"extensions"`
+	Transactions          []*types.Transaction        `json:"transactions"`
+	TransactionIDs        []string                    `json:"transaction_ids"`
+
+	// VirtualOperations is not part of get_block, it is filled in
+	// by the stream package when virtual operations are requested.
+	VirtualOperations []*AppliedOperation `json:"-"`
+}
+
+// AppliedOperation is an operation as applied by the blockchain,
+// i.e. an item returned by get_ops_in_block or get_account_history.
+//
+// Virtual operations generated while processing the block rather than
+// a transaction have TrxInBlock out of the range of the block transactions.
+type AppliedOperation struct {
+	TrxID      string          `json:"trx_id"`
+	BlockNum   uint32          `json:"block"`
+	TrxInBlock uint32          `json:"trx_in_block"`
+	OpInTrx    uint32          `json:"op_in_trx"`
+	VirtualOp  uint64          `json:"virtual_op"`
+	Timestamp  *types.Time     `json:"timestamp"`
+	Operation  types.Operation `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (op *AppliedOperation) UnmarshalJSON(data []byte) error {
+	type appliedOperation AppliedOperation
+	var resp struct {
+		*appliedOperation
+		Op json.RawMessage `json:"op"`
+	}
+	resp.appliedOperation = (*appliedOperation)(op)
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+
+	// Reuse types.Operations to unmarshal the [opType, opBody] object.
+	var ops types.Operations
+	if err := json.Unmarshal([]byte("["+string(resp.Op)+"]"), &ops); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal AppliedOperation.Operation: %v", string(resp.Op))
+	}
+	if len(ops) != 1 {
+		return errors.Errorf("invalid applied operation object: %v", string(data))
+	}
+	op.Operation = ops[0]
+	return nil
+}
+
+// AccountHistoryEntry is an item returned by get_account_history.
+type AccountHistoryEntry struct {
+	Index     uint64
+	Operation *AppliedOperation
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (entry *AccountHistoryEntry) UnmarshalJSON(data []byte) error {
+	// The history entry object is [index, operation].
+	raw := make([]json.RawMessage, 2)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return errors.Errorf("invalid account history entry: %v", string(data))
+	}
+
+	if err := json.Unmarshal(raw[0], &entry.Index); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal AccountHistoryEntry.Index: %v", string(raw[0]))
+	}
+	if err := json.Unmarshal(raw[1], &entry.Operation); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal AccountHistoryEntry.Operation: %v", string(raw[1]))
+	}
+	return nil
 }
 
 type Content struct {
@@ -219,8 +324,8 @@ type Content struct {
 	RootTitle               string           `json:"root_title"`
 	Active                  *types.Time      `json:"active"`
 	AbsRshares              *types.Int       `json:"abs_rshares"`
-	PendingPayoutValue      string           `json:"pending_payout_value"`
-	TotalPendingPayoutValue string           `json:"total_pending_payout_value"`
+	PendingPayoutValue      *types.Asset     `json:"pending_payout_value"`
+	TotalPendingPayoutValue *types.Asset     `json:"total_pending_payout_value"`
 	Category                string           `json:"category"`
 	Title                   string           `json:"title"`
 	LastUpdate              *types.Time      `json:"last_update"`
@@ -236,10 +341,11 @@ type Content struct {
 	ActiveVotes             []*VoteState     `json:"active_votes"`
 	ParentPermlink          string           `json:"parent_permlink"`
 	CashoutTime             *types.Time      `json:"cashout_time"`
-	TotalPayoutValue        string           `json:"total_payout_value"`
+	TotalPayoutValue        *types.Asset     `json:"total_payout_value"`
 	ParentAuthor            string           `json:"parent_author"`
-	ChildrenRshares2        *types.Int       `json:"children_rshares2"`
+	ChildrenRshares2        *types.BigInt    `json:"children_rshares2"`
 	Author                  string           `json:"author"`
+	AuthorReputation        *types.Int       `json:"author_reputation"`
 	Depth                   *types.Int       `json:"depth"`
 	TotalVoteWeight         *types.Int       `json:"total_vote_weight"`
 }
@@ -313,3 +419,154 @@ type Profile struct {
 	Website      string `json:"website"`
 	About        string `json:"about"`
 }
+
+// Price represents an exchange rate, e.g. the feed price.
+type Price = types.Price
+
+// State represents the get_state response,
+// i.e. everything a web frontend needs to render the given path.
+//
+// Discussion indexes and account blogs and feeds contain references
+// in the author/permlink form pointing into the Content map,
+// these can be turned into discussions using ResolveContent.
+type State struct {
+	CurrentRoute  string                      `json:"current_route"`
+	Props         *DynamicGlobalProperties    `json:"props"`
+	TagIndex      *TagIndex                   `json:"tag_idx"`
+	DiscussionIdx map[string]*DiscussionIndex `json:"discussion_idx"`
+	Tags          map[string]*Tag             `json:"tags"`
+	Accounts      map[string]*ExtendedAccount `json:"accounts"`
+	Content       map[string]*Content         `json:"content"`
+	FeedPrice     *Price                      `json:"feed_price"`
+	Error         string                      `json:"error"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// The maps are accepted both as JSON objects
+// and as arrays of [key, value] pairs, depending on the node version.
+func (state *State) UnmarshalJSON(data []byte) error {
+	type stateAlias State
+	raw := struct {
+		*stateAlias
+		DiscussionIdx json.RawMessage `json:"discussion_idx"`
+		Tags          json.RawMessage `json:"tags"`
+		Accounts      json.RawMessage `json:"accounts"`
+		Content       json.RawMessage `json:"content"`
+	}{
+		stateAlias: (*stateAlias)(state),
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if err := unmarshalStringMap(raw.DiscussionIdx, &state.DiscussionIdx); err != nil {
+		return errors.Wrap(err, "failed to unmarshal State.DiscussionIdx")
+	}
+	if err := unmarshalStringMap(raw.Tags, &state.Tags); err != nil {
+		return errors.Wrap(err, "failed to unmarshal State.Tags")
+	}
+	if err := unmarshalStringMap(raw.Accounts, &state.Accounts); err != nil {
+		return errors.Wrap(err, "failed to unmarshal State.Accounts")
+	}
+	if err := unmarshalStringMap(raw.Content, &state.Content); err != nil {
+		return errors.Wrap(err, "failed to unmarshal State.Content")
+	}
+	return nil
+}
+
+// unmarshalStringMap unmarshals either {"key": value, ...} or [["key", value], ...]
+// into the map v is pointing to.
+func unmarshalStringMap(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	if data[0] != '[' {
+		return json.Unmarshal(data, v)
+	}
+
+	var pairs [][]json.RawMessage
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return err
+	}
+
+	mapValue := reflect.ValueOf(v).Elem()
+	mapValue.Set(reflect.MakeMapWithSize(mapValue.Type(), len(pairs)))
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return errors.Errorf("invalid map entry: %v", pair)
+		}
+
+		var key string
+		if err := json.Unmarshal(pair[0], &key); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal map key: %v", string(pair[0]))
+		}
+
+		value := reflect.New(mapValue.Type().Elem())
+		if err := json.Unmarshal(pair[1], value.Interface()); err != nil {
+			return errors.Wrapf(err, "failed to unmarshal map value for key %v", key)
+		}
+		mapValue.SetMapIndex(reflect.ValueOf(key), value.Elem())
+	}
+	return nil
+}
+
+// ResolveContent turns author/permlink references into discussions
+// using the Content map. References not present in the map are skipped.
+func (state *State) ResolveContent(refs []string) []*Content {
+	resp := make([]*Content, 0, len(refs))
+	for _, ref := range refs {
+		if content, ok := state.Content[ref]; ok {
+			resp = append(resp, content)
+		}
+	}
+	return resp
+}
+
+// TagIndex lists the trending tags.
+type TagIndex struct {
+	Trending []string `json:"trending"`
+}
+
+// DiscussionIndex lists discussion references for the given category
+// sorted in various ways. The empty category stands for all categories.
+type DiscussionIndex struct {
+	Category       string   `json:"category"`
+	Trending       []string `json:"trending"`
+	Payout         []string `json:"payout"`
+	PayoutComments []string `json:"payout_comments"`
+	Trending30     []string `json:"trending30"`
+	Updated        []string `json:"updated"`
+	Created        []string `json:"created"`
+	Responses      []string `json:"responses"`
+	Active         []string `json:"active"`
+	Votes          []string `json:"votes"`
+	Maturing       []string `json:"maturing"`
+	Best           []string `json:"best"`
+	Hot            []string `json:"hot"`
+	Promoted       []string `json:"promoted"`
+	Cashout        []string `json:"cashout"`
+}
+
+// Tag represents tag statistics.
+type Tag struct {
+	Name                  string     `json:"name"`
+	TotalChildrenRshares2 string     `json:"total_children_rshares2"`
+	TotalPayouts          string     `json:"total_payouts"`
+	NetVotes              int32      `json:"net_votes"`
+	TopPosts              uint32     `json:"top_posts"`
+	Comments              uint32     `json:"comments"`
+	Trending              *types.Int `json:"trending"`
+}
+
+// ExtendedAccount is an account as returned by get_state,
+// carrying references to the account discussions on top of Account.
+type ExtendedAccount struct {
+	*Account
+	Reputation    *types.Int `json:"reputation"`
+	Blog          []string   `json:"blog"`
+	Feed          []string   `json:"feed"`
+	Comments      []string   `json:"comments"`
+	RecentReplies []string   `json:"recent_replies"`
+	Recommended   []string   `json:"recommended"`
+}
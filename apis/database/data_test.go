@@ -0,0 +1,82 @@
+package database
+
+import (
+	// Stdlib
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestState_UnmarshalJSON(t *testing.T) {
+	// The same state with the maps encoded as objects and as [key, value] pairs.
+	objects := `{
+		"current_route": "/trending/scorum",
+		"tag_idx": {"trending": ["scorum", "life"]},
+		"discussion_idx": {"scorum": {"category": "scorum", "trending": ["alice/hello"]}},
+		"tags": {"scorum": {"name": "scorum", "net_votes": 3, "top_posts": 1, "comments": 2}},
+		"accounts": {"alice": {"name": "alice", "reputation": "12345678", "blog": ["alice/hello"]}},
+		"content": {"alice/hello": {"author": "alice", "permlink": "hello", "title": "Hello"}},
+		"error": ""
+	}`
+	pairs := `{
+		"current_route": "/trending/scorum",
+		"tag_idx": {"trending": ["scorum", "life"]},
+		"discussion_idx": [["scorum", {"category": "scorum", "trending": ["alice/hello"]}]],
+		"tags": [["scorum", {"name": "scorum", "net_votes": 3, "top_posts": 1, "comments": 2}]],
+		"accounts": [["alice", {"name": "alice", "reputation": "12345678", "blog": ["alice/hello"]}]],
+		"content": [["alice/hello", {"author": "alice", "permlink": "hello", "title": "Hello"}]],
+		"error": ""
+	}`
+
+	var fromObjects, fromPairs State
+	if err := json.Unmarshal([]byte(objects), &fromObjects); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(pairs), &fromPairs); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, state := range map[string]*State{"objects": &fromObjects, "pairs": &fromPairs} {
+		if state.CurrentRoute != "/trending/scorum" || len(state.TagIndex.Trending) != 2 {
+			t.Errorf("%v: unexpected state: %+v", name, state)
+		}
+		if idx := state.DiscussionIdx["scorum"]; idx == nil || idx.Trending[0] != "alice/hello" {
+			t.Errorf("%v: unexpected discussion index: %+v", name, state.DiscussionIdx)
+		}
+		if tag := state.Tags["scorum"]; tag == nil || tag.NetVotes != 3 || tag.Comments != 2 {
+			t.Errorf("%v: unexpected tags: %+v", name, state.Tags)
+		}
+		account := state.Accounts["alice"]
+		if account == nil || account.Name != "alice" || account.Reputation.Int64() != 12345678 {
+			t.Errorf("%v: unexpected accounts: %+v", name, state.Accounts)
+		}
+		contents := state.ResolveContent(account.Blog)
+		if len(contents) != 1 || contents[0].Title != "Hello" {
+			t.Errorf("%v: unexpected blog: %+v", name, contents)
+		}
+	}
+
+	if !reflect.DeepEqual(fromObjects, fromPairs) {
+		t.Errorf("expected both forms to be equal:\n%+v\n%+v", fromObjects, fromPairs)
+	}
+}
+
+func TestState_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, data := range []string{
+		// The pair is missing the value.
+		`{"tags": [["scorum"]]}`,
+		// The pair has an extra element.
+		`{"tags": [["scorum", {}, {}]]}`,
+		// The key is not a string.
+		`{"content": [[1, {"author": "alice"}]]}`,
+		// The value does not match the map type.
+		`{"accounts": [["alice", "bob"]]}`,
+		// Neither an object nor an array of pairs.
+		`{"discussion_idx": "scorum"}`,
+	} {
+		var state State
+		if err := json.Unmarshal([]byte(data), &state); err == nil {
+			t.Errorf("%v: expected an error", data)
+		}
+	}
+}
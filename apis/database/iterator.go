@@ -0,0 +1,232 @@
+package database
+
+import (
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// DiscussionFetcher fetches a single page of discussions for the given query,
+// e.g. API.GetDiscussionsByCreated.
+type DiscussionFetcher func(query *DiscussionQuery) ([]*Content, error)
+
+// DiscussionIterator pages through a discussion listing.
+//
+// Every page after the first one starts at the last discussion
+// of the previous page, the iterator takes care of dropping the duplicate.
+//
+//	it := database.NewDiscussionIterator(client.Database.GetDiscussionsByCreated, query)
+//	for it.Next() {
+//		content := it.Content()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type DiscussionIterator struct {
+	fetch DiscussionFetcher
+	query DiscussionQuery
+
+	page    []*Content
+	current *Content
+	last    *Content
+	done    bool
+	err     error
+}
+
+// NewDiscussionIterator returns an iterator calling fetch repeatedly,
+// starting with the given query. The query is copied including the tag and author lists,
+// so it can be reused or modified.
+// An invalid query is reported by Err.
+func NewDiscussionIterator(fetch DiscussionFetcher, query *DiscussionQuery) *DiscussionIterator {
+	it := &DiscussionIterator{fetch: fetch}
+	switch {
+	case query == nil:
+		it.err = errors.New("DiscussionIterator: query not set")
+	case query.Limit < 2:
+		it.err = errors.New("DiscussionIterator: limit must be at least 2")
+	default:
+		it.query = *query
+		it.query.FilterTags = append([]string(nil), query.FilterTags...)
+		it.query.SelectAuthors = append([]string(nil), query.SelectAuthors...)
+		it.query.SelectTags = append([]string(nil), query.SelectTags...)
+	}
+	return it
+}
+
+// IterateRepliesByLastUpdate returns an iterator over get_replies_by_last_update.
+//
+// The arguments have the same meaning as for GetRepliesByLastUpdate,
+// limit being the page size.
+func (api *API) IterateRepliesByLastUpdate(
+	startAuthor string,
+	startPermlink string,
+	limit uint32,
+) *DiscussionIterator {
+
+	fetch := func(query *DiscussionQuery) ([]*Content, error) {
+		return api.GetRepliesByLastUpdate(query.StartAuthor, query.StartPermlink, query.Limit)
+	}
+
+	return NewDiscussionIterator(fetch, &DiscussionQuery{
+		Limit:         limit,
+		StartAuthor:   startAuthor,
+		StartPermlink: startPermlink,
+	})
+}
+
+// Next advances the iterator to the next discussion.
+// It returns false when there are no more discussions or an error occurred.
+func (it *DiscussionIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.load(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Content returns the discussion the iterator is currently pointing at.
+func (it *DiscussionIterator) Content() *Content {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *DiscussionIterator) Err() error {
+	return it.err
+}
+
+func (it *DiscussionIterator) load() error {
+	page, err := it.fetch(&it.query)
+	if err != nil {
+		return err
+	}
+
+	// A short page means we have reached the end of the listing.
+	if uint32(len(page)) < it.query.Limit {
+		it.done = true
+	}
+	if len(page) == 0 {
+		it.done = true
+		return nil
+	}
+
+	// The next page is going to start at the last item of this page.
+	last := page[len(page)-1]
+	it.query.StartAuthor = last.Author
+	it.query.StartPermlink = last.Permlink
+
+	// Drop the item returned as the last item of the previous page.
+	if it.last != nil && page[0].Author == it.last.Author && page[0].Permlink == it.last.Permlink {
+		page = page[1:]
+	}
+	it.last = last
+
+	// Nothing new was returned, we are not going to move any further.
+	if len(page) == 0 {
+		it.done = true
+	}
+
+	it.page = page
+	return nil
+}
+
+// AccountNameIterator pages through lookup_accounts.
+type AccountNameIterator struct {
+	api   *API
+	start string
+	limit uint32
+
+	page    []string
+	current string
+	first   bool
+	done    bool
+	err     error
+}
+
+// IterateAccountNames returns an iterator over all account names
+// starting with lowerBoundName, fetching limit names per call.
+func (api *API) IterateAccountNames(lowerBoundName string, limit uint32) *AccountNameIterator {
+	it := &AccountNameIterator{api: api, start: lowerBoundName, limit: limit, first: true}
+	if limit < 2 {
+		it.err = errors.New("AccountNameIterator: limit must be at least 2")
+	}
+	return it
+}
+
+// Next advances the iterator to the next account name.
+// It returns false when there are no more names or an error occurred.
+func (it *AccountNameIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.load(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Name returns the account name the iterator is currently pointing at.
+func (it *AccountNameIterator) Name() string {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *AccountNameIterator) Err() error {
+	return it.err
+}
+
+func (it *AccountNameIterator) load() error {
+	page, err := it.api.LookupAccounts(it.start, it.limit)
+	if err != nil {
+		return err
+	}
+
+	if uint32(len(page)) < it.limit {
+		it.done = true
+	}
+	if len(page) == 0 {
+		it.done = true
+		return nil
+	}
+
+	// The lower bound is inclusive, so every page after the first one
+	// starts with the last name of the previous page.
+	last := page[len(page)-1]
+	if !it.first && page[0] == it.start {
+		page = page[1:]
+	}
+	it.first = false
+	it.start = last
+
+	if len(page) == 0 {
+		it.done = true
+	}
+
+	it.page = page
+	return nil
+}
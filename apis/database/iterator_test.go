@@ -0,0 +1,170 @@
+package database
+
+import (
+	// Stdlib
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// listing emulates a node listing where the start item is inclusive.
+type listing struct {
+	items []string
+	fail  int // fail the call with this index, counting from 1
+	calls int
+}
+
+func (l *listing) page(start string, limit int) ([]string, error) {
+	l.calls++
+	if l.calls == l.fail {
+		return nil, errors.New("connection lost")
+	}
+
+	from := 0
+	if start != "" {
+		for from < len(l.items) && l.items[from] != start {
+			from++
+		}
+	}
+	to := from + limit
+	if to > len(l.items) {
+		to = len(l.items)
+	}
+	return l.items[from:to], nil
+}
+
+func names(n int) []string {
+	items := make([]string, n)
+	for i := range items {
+		items[i] = fmt.Sprintf("item-%02d", i)
+	}
+	return items
+}
+
+var iteratorTests = []struct {
+	name  string
+	items []string
+	limit int
+	fail  int
+	calls int
+	err   bool
+}{
+	{name: "empty first page", items: nil, limit: 5, calls: 1},
+	{name: "short first page", items: names(3), limit: 5, calls: 1},
+	{name: "exactly limit results", items: names(5), limit: 5, calls: 2},
+	{name: "page with only the cursor item", items: names(9), limit: 5, calls: 3},
+	{name: "several pages", items: names(12), limit: 5, calls: 3},
+	{name: "error on first page", items: names(12), limit: 5, fail: 1, calls: 1, err: true},
+	{name: "error on second page", items: names(12), limit: 5, fail: 2, calls: 2, err: true},
+}
+
+func TestDiscussionIterator(t *testing.T) {
+	for _, test := range iteratorTests {
+		l := &listing{items: test.items, fail: test.fail}
+		fetch := func(query *DiscussionQuery) ([]*Content, error) {
+			page, err := l.page(query.StartPermlink, int(query.Limit))
+			if err != nil {
+				return nil, err
+			}
+			var contents []*Content
+			for _, permlink := range page {
+				contents = append(contents, &Content{Author: "alice", Permlink: permlink})
+			}
+			return contents, nil
+		}
+
+		var got []string
+		it := NewDiscussionIterator(fetch, NewDiscussionQuery(uint32(test.limit)))
+		for it.Next() {
+			got = append(got, it.Content().Permlink)
+		}
+
+		checkIteration(t, test.name, test.items, got, it.Err(), test.err, l.calls, test.calls)
+	}
+}
+
+func TestAccountNameIterator(t *testing.T) {
+	for _, test := range iteratorTests {
+		l := &listing{items: test.items, fail: test.fail}
+		api := NewAPI(callerFunc(func(method string, params interface{}) (interface{}, error) {
+			if method != "lookup_accounts" {
+				t.Fatalf("unexpected method: %v", method)
+			}
+			args := params.([]interface{})
+			return l.page(args[0].(string), int(args[1].(uint32)))
+		}))
+
+		var got []string
+		it := api.IterateAccountNames("", uint32(test.limit))
+		for it.Next() {
+			got = append(got, it.Name())
+		}
+
+		checkIteration(t, test.name, test.items, got, it.Err(), test.err, l.calls, test.calls)
+	}
+}
+
+func checkIteration(t *testing.T, name string, items, got []string, err error, expectErr bool, calls, expectCalls int) {
+	t.Helper()
+
+	if expectErr {
+		if err == nil {
+			t.Errorf("%v: expected an error", name)
+		}
+	} else {
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, items) {
+			t.Errorf("%v: expected %v, got %v", name, items, got)
+		}
+	}
+	if calls != expectCalls {
+		t.Errorf("%v: expected %v calls, got %v", name, expectCalls, calls)
+	}
+}
+
+func TestNewDiscussionIterator_Invalid(t *testing.T) {
+	fetch := func(query *DiscussionQuery) ([]*Content, error) {
+		t.Error("unexpected fetch")
+		return nil, nil
+	}
+
+	for _, query := range []*DiscussionQuery{nil, NewDiscussionQuery(1)} {
+		it := NewDiscussionIterator(fetch, query)
+		if it.Next() {
+			t.Errorf("%+v: expected no items", query)
+		}
+		if it.Err() == nil {
+			t.Errorf("%+v: expected an error", query)
+		}
+	}
+}
+
+func TestNewDiscussionIterator_CopiesQuery(t *testing.T) {
+	query := NewDiscussionQuery(10).WithTag("scorum").WithFilterTags("nsfw").WithSelectAuthors("alice")
+
+	var got []*DiscussionQuery
+	fetch := func(q *DiscussionQuery) ([]*Content, error) {
+		copied := *q
+		got = append(got, &copied)
+		return nil, nil
+	}
+	it := NewDiscussionIterator(fetch, query)
+
+	// Modifying the query in place must not affect the iterator.
+	query.FilterTags[0] = "spam"
+	query.SelectAuthors[0] = "mallory"
+	query.WithSelectTags("football")
+
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	expected := NewDiscussionQuery(10).WithTag("scorum").WithFilterTags("nsfw").WithSelectAuthors("alice")
+	if len(got) != 1 || !reflect.DeepEqual(got[0], expected) {
+		t.Errorf("expected %+v, got %+v", expected, got)
+	}
+}
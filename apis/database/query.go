@@ -0,0 +1,108 @@
+package database
+
+import (
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// DiscussionQueryMaxLimit is the maximum number of discussions
+// the node is willing to return for a single discussion query.
+const DiscussionQueryMaxLimit = 100
+
+// DiscussionQuery represents the discussion_query object accepted
+// by get_discussions_by_* methods.
+//
+// Use NewDiscussionQuery and chain the With* methods to build a query.
+// The query is validated before being sent to the node.
+type DiscussionQuery struct {
+	Tag            string   `json:"tag"`
+	Limit          uint32   `json:"limit"`
+	FilterTags     []string `json:"filter_tags,omitempty"`
+	SelectAuthors  []string `json:"select_authors,omitempty"`
+	SelectTags     []string `json:"select_tags,omitempty"`
+	TruncateBody   uint32   `json:"truncate_body,omitempty"`
+	StartAuthor    string   `json:"start_author,omitempty"`
+	StartPermlink  string   `json:"start_permlink,omitempty"`
+	ParentAuthor   string   `json:"parent_author,omitempty"`
+	ParentPermlink string   `json:"parent_permlink,omitempty"`
+}
+
+// NewDiscussionQuery returns a new query returning at most limit discussions.
+func NewDiscussionQuery(limit uint32) *DiscussionQuery {
+	return &DiscussionQuery{Limit: limit}
+}
+
+// WithTag sets the tag the discussions are looked up by.
+func (query *DiscussionQuery) WithTag(tag string) *DiscussionQuery {
+	query.Tag = tag
+	return query
+}
+
+// WithFilterTags excludes discussions carrying any of the given tags.
+func (query *DiscussionQuery) WithFilterTags(tags ...string) *DiscussionQuery {
+	query.FilterTags = append(query.FilterTags, tags...)
+	return query
+}
+
+// WithSelectAuthors limits the result to discussions by the given authors.
+func (query *DiscussionQuery) WithSelectAuthors(authors ...string) *DiscussionQuery {
+	query.SelectAuthors = append(query.SelectAuthors, authors...)
+	return query
+}
+
+// WithSelectTags limits the result to discussions carrying any of the given tags.
+func (query *DiscussionQuery) WithSelectTags(tags ...string) *DiscussionQuery {
+	query.SelectTags = append(query.SelectTags, tags...)
+	return query
+}
+
+// WithTruncateBody makes the node truncate Content.Body to the given number of bytes.
+// Zero means the body is returned in full.
+func (query *DiscussionQuery) WithTruncateBody(length uint32) *DiscussionQuery {
+	query.TruncateBody = length
+	return query
+}
+
+// WithStart makes the listing start at the given discussion, inclusive.
+func (query *DiscussionQuery) WithStart(author, permlink string) *DiscussionQuery {
+	query.StartAuthor = author
+	query.StartPermlink = permlink
+	return query
+}
+
+// WithParent limits the result to replies to the given discussion.
+func (query *DiscussionQuery) WithParent(author, permlink string) *DiscussionQuery {
+	query.ParentAuthor = author
+	query.ParentPermlink = permlink
+	return query
+}
+
+// Validate checks the query against the rules enforced by the node,
+// so that an invalid query is rejected before it is sent over the wire.
+// A nil query is invalid.
+func (query *DiscussionQuery) Validate() error {
+	if query == nil {
+		return errors.New("DiscussionQuery: query not set")
+	}
+	if query.Limit == 0 {
+		return errors.New("DiscussionQuery: limit must be greater than 0")
+	}
+	if query.Limit > DiscussionQueryMaxLimit {
+		return errors.Errorf(
+			"DiscussionQuery: limit must not exceed %v", DiscussionQueryMaxLimit)
+	}
+
+	if (query.StartAuthor == "") != (query.StartPermlink == "") {
+		return errors.New("DiscussionQuery: start author and start permlink must be set together")
+	}
+
+	for _, filtered := range query.FilterTags {
+		for _, selected := range query.SelectTags {
+			if filtered == selected {
+				return errors.Errorf(
+					"DiscussionQuery: tag %v is both filtered and selected", filtered)
+			}
+		}
+	}
+	return nil
+}
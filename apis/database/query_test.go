@@ -0,0 +1,72 @@
+package database
+
+import (
+	// Stdlib
+	"reflect"
+	"testing"
+)
+
+func TestDiscussionQuery_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *DiscussionQuery
+		valid bool
+	}{
+		{"nil query", nil, false},
+		{"zero limit", NewDiscussionQuery(0), false},
+		{"minimum limit", NewDiscussionQuery(1), true},
+		{"maximum limit", NewDiscussionQuery(DiscussionQueryMaxLimit), true},
+		{"limit too big", NewDiscussionQuery(DiscussionQueryMaxLimit + 1), false},
+		{"tag only", NewDiscussionQuery(10).WithTag("scorum"), true},
+		{"tag and start", NewDiscussionQuery(10).WithTag("scorum").WithStart("alice", "hello"), true},
+		{"start author only", NewDiscussionQuery(10).WithTag("scorum").WithStart("alice", ""), false},
+		{"start permlink only", NewDiscussionQuery(10).WithTag("scorum").WithStart("", "hello"), false},
+		{"parent", NewDiscussionQuery(10).WithParent("alice", "hello"), true},
+		{
+			"filtered and selected tag",
+			NewDiscussionQuery(10).WithFilterTags("nsfw").WithSelectTags("life", "nsfw"),
+			false,
+		},
+		{
+			"filtered and selected tags differ",
+			NewDiscussionQuery(10).WithFilterTags("nsfw").WithSelectTags("life").WithSelectAuthors("alice"),
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		err := test.query.Validate()
+		if test.valid && err != nil {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%v: expected an error", test.name)
+		}
+	}
+}
+
+func TestDiscussionQuery_Builder(t *testing.T) {
+	query := NewDiscussionQuery(20).
+		WithTag("scorum").
+		WithFilterTags("nsfw").
+		WithFilterTags("spam").
+		WithSelectAuthors("alice", "bob").
+		WithTruncateBody(100).
+		WithStart("alice", "hello").
+		WithParent("bob", "root")
+
+	expected := DiscussionQuery{
+		Tag:            "scorum",
+		Limit:          20,
+		FilterTags:     []string{"nsfw", "spam"},
+		SelectAuthors:  []string{"alice", "bob"},
+		TruncateBody:   100,
+		StartAuthor:    "alice",
+		StartPermlink:  "hello",
+		ParentAuthor:   "bob",
+		ParentPermlink: "root",
+	}
+	if !reflect.DeepEqual(*query, expected) {
+		t.Errorf("expected %+v, got %+v", expected, *query)
+	}
+}
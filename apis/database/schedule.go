@@ -0,0 +1,115 @@
+package database
+
+import (
+	// Stdlib
+	"time"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// Witnesses returns the production order of the current round,
+// dropping the unused slots of CurrentShuffledWitnesses.
+func (schedule *WitnessSchedule) Witnesses() []string {
+	witnesses := schedule.CurrentShuffledWitnesses
+	if n := int(schedule.NumScheduledWitnesses); n != 0 && n < len(witnesses) {
+		witnesses = witnesses[:n]
+	}
+	return witnesses
+}
+
+// ProductionSchedule predicts which witness produces which block slot and when,
+// the same way the node does when scheduling block production.
+//
+// Slots are numbered relative to the head block, slot 1 being the first slot
+// after the head block. Every slot takes Config.ScorumBlockInterval seconds
+// and a slot is missed when the scheduled witness does not produce a block.
+type ProductionSchedule struct {
+	// Witnesses is the production order of the current round.
+	Witnesses []string
+
+	// Interval is the block interval.
+	Interval time.Duration
+
+	// HeadBlockNum, HeadTime and HeadAslot describe the head block
+	// the predictions are relative to.
+	HeadBlockNum uint32
+	HeadTime     time.Time
+	HeadAslot    uint64
+
+	// NextShuffleBlockNum is the block after which the witnesses are shuffled again.
+	NextShuffleBlockNum uint32
+}
+
+// NewProductionSchedule creates a production schedule from the current
+// witness schedule, dynamic global properties and chain config.
+func NewProductionSchedule(
+	schedule *WitnessSchedule,
+	props *DynamicGlobalProperties,
+	config *Config,
+) (*ProductionSchedule, error) {
+
+	if config.ScorumBlockInterval <= 0 {
+		return nil, errors.Errorf("invalid block interval: %v", config.ScorumBlockInterval)
+	}
+	if props.Time.Time == nil {
+		return nil, errors.New("head block time not set")
+	}
+
+	witnesses := schedule.Witnesses()
+	if len(witnesses) == 0 {
+		return nil, errors.New("no witnesses scheduled")
+	}
+
+	return &ProductionSchedule{
+		Witnesses:           witnesses,
+		Interval:            time.Duration(config.ScorumBlockInterval) * time.Second,
+		HeadBlockNum:        uint32(props.HeadBlockNumber),
+		HeadTime:            *props.Time.Time,
+		HeadAslot:           uint64(props.CurrentAslot),
+		NextShuffleBlockNum: schedule.NextShuffleBlockNum,
+	}, nil
+}
+
+// Witness returns the witness scheduled to produce the given slot.
+func (schedule *ProductionSchedule) Witness(slot uint32) string {
+	aslot := schedule.HeadAslot + uint64(slot)
+	return schedule.Witnesses[aslot%uint64(len(schedule.Witnesses))]
+}
+
+// SlotTime returns the time the given slot starts at,
+// i.e. the timestamp of the block produced in the slot.
+func (schedule *ProductionSchedule) SlotTime(slot uint32) time.Time {
+	// Slots are aligned to the Unix epoch.
+	interval := int64(schedule.Interval / time.Second)
+	headSlot := schedule.HeadTime.Unix() / interval
+	return time.Unix((headSlot+int64(slot))*interval, 0).UTC()
+}
+
+// SlotAt returns the slot in progress at the given time.
+// Zero is returned for any time before the first slot after the head block.
+func (schedule *ProductionSchedule) SlotAt(t time.Time) uint32 {
+	first := schedule.SlotTime(1)
+	if t.Before(first) {
+		return 0
+	}
+	return uint32(t.Sub(first)/schedule.Interval) + 1
+}
+
+// IsReliable returns false when the witnesses may be shuffled
+// before the given slot is reached, i.e. when the prediction may be wrong.
+func (schedule *ProductionSchedule) IsReliable(slot uint32) bool {
+	return uint64(schedule.HeadBlockNum)+uint64(slot) <= uint64(schedule.NextShuffleBlockNum)
+}
+
+// NextSlot returns the first slot after the head block scheduled
+// for the given witness. False is returned when the witness is not scheduled
+// in the current round.
+func (schedule *ProductionSchedule) NextSlot(witness string) (uint32, bool) {
+	for slot := uint32(1); slot <= uint32(len(schedule.Witnesses)); slot++ {
+		if schedule.Witness(slot) == witness {
+			return slot, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,73 @@
+package database
+
+import (
+	// Stdlib
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/types"
+)
+
+func newTestSchedule(t *testing.T) *ProductionSchedule {
+	headTime := time.Date(2018, 3, 1, 12, 0, 4, 0, time.UTC)
+	schedule, err := NewProductionSchedule(
+		&WitnessSchedule{
+			NextShuffleBlockNum:      102,
+			CurrentShuffledWitnesses: []string{"alice", "bob", "carol", ""},
+			NumScheduledWitnesses:    3,
+		},
+		&DynamicGlobalProperties{
+			Time:            types.Time{Time: &headTime},
+			HeadBlockNumber: 100,
+			CurrentAslot:    1000,
+		},
+		&Config{ScorumBlockInterval: 3},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func TestProductionSchedule_Witness(t *testing.T) {
+	schedule := newTestSchedule(t)
+
+	// Aslot 1000 % 3 == 1, so the head block was produced by bob.
+	for slot, expected := range []string{"bob", "carol", "alice", "bob", "carol"} {
+		if got := schedule.Witness(uint32(slot)); got != expected {
+			t.Errorf("slot %v: expected %v, got %v", slot, expected, got)
+		}
+	}
+
+	if slot, ok := schedule.NextSlot("alice"); !ok || slot != 2 {
+		t.Errorf("expected alice to produce slot 2, got %v, %v", slot, ok)
+	}
+	if _, ok := schedule.NextSlot("mallory"); ok {
+		t.Error("expected mallory not to be scheduled")
+	}
+
+	if !schedule.IsReliable(2) || schedule.IsReliable(3) {
+		t.Error("expected slots up to 2 to be reliable")
+	}
+}
+
+func TestProductionSchedule_SlotTime(t *testing.T) {
+	schedule := newTestSchedule(t)
+
+	// The head block time is 12:00:04, i.e. within the slot starting at 12:00:03.
+	expected := time.Date(2018, 3, 1, 12, 0, 9, 0, time.UTC)
+	if got := schedule.SlotTime(2); !got.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if slot := schedule.SlotAt(expected); slot != 2 {
+		t.Errorf("expected slot 2, got %v", slot)
+	}
+	if slot := schedule.SlotAt(expected.Add(2 * time.Second)); slot != 2 {
+		t.Errorf("expected slot 2, got %v", slot)
+	}
+	if slot := schedule.SlotAt(schedule.HeadTime); slot != 0 {
+		t.Errorf("expected slot 0, got %v", slot)
+	}
+}
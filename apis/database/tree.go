@@ -0,0 +1,157 @@
+package database
+
+import (
+	// Stdlib
+	"sync"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// DefaultTreeConcurrency is the default number of get_content_replies calls
+// GetDiscussionTree keeps in flight.
+const DefaultTreeConcurrency = 8
+
+type treeOptions struct {
+	concurrency int
+	maxDepth    int
+}
+
+// TreeOption represents an option that can be passed into GetDiscussionTree.
+type TreeOption func(*treeOptions)
+
+// SetTreeConcurrency sets the maximum number of concurrent get_content_replies calls.
+func SetTreeConcurrency(concurrency int) TreeOption {
+	return func(opts *treeOptions) {
+		opts.concurrency = concurrency
+	}
+}
+
+// SetTreeMaxDepth limits how many levels of replies below the root are fetched.
+// Zero means there is no limit.
+func SetTreeMaxDepth(depth int) TreeOption {
+	return func(opts *treeOptions) {
+		opts.maxDepth = depth
+	}
+}
+
+// GetDiscussionTree fetches the given discussion together with all its replies.
+//
+// The replies are fetched level by level using get_content_replies,
+// and Content.Replies is filled in for every node of the returned tree.
+// Every discussion is fetched at most once, so a misbehaving node
+// returning a reply cycle cannot make the function loop forever.
+func (api *API) GetDiscussionTree(author, permlink string, options ...TreeOption) (*Content, error) {
+	opts := treeOptions{concurrency: DefaultTreeConcurrency}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.concurrency < 1 {
+		opts.concurrency = 1
+	}
+
+	root, err := api.GetContent(author, permlink)
+	if err != nil {
+		return nil, err
+	}
+	if root.Author == "" {
+		return nil, errors.Errorf("GetDiscussionTree: content not found: @%v/%v", author, permlink)
+	}
+	root.Replies = nil
+
+	visited := map[string]bool{contentKey(root): true}
+	level := []*Content{root}
+
+	for depth := 0; len(level) != 0; depth++ {
+		if opts.maxDepth != 0 && depth >= opts.maxDepth {
+			break
+		}
+
+		if err := api.fetchReplies(level, opts.concurrency); err != nil {
+			return nil, err
+		}
+
+		var next []*Content
+		for _, parent := range level {
+			replies := make([]*Content, 0, len(parent.Replies))
+			for _, reply := range parent.Replies {
+				key := contentKey(reply)
+				if visited[key] {
+					continue
+				}
+				visited[key] = true
+				reply.Replies = nil
+				replies = append(replies, reply)
+			}
+			parent.Replies = replies
+			next = append(next, replies...)
+		}
+		level = next
+	}
+
+	return root, nil
+}
+
+// fetchReplies sets Content.Replies for all the given discussions,
+// keeping at most concurrency calls running at the same time.
+func (api *API) fetchReplies(parents []*Content, concurrency int) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	failed := func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return firstErr != nil
+	}
+
+	semaphore := make(chan struct{}, concurrency)
+	for _, parent := range parents {
+		// Skip the call when we know there are no replies.
+		if parent.Children != nil && parent.Children.Int != nil && parent.Children.Sign() == 0 {
+			parent.Replies = nil
+			continue
+		}
+
+		// Stop starting new calls once a call has failed.
+		// A call may fail while we are waiting for a free slot, so check again after that.
+		if failed() {
+			break
+		}
+		semaphore <- struct{}{}
+		if failed() {
+			<-semaphore
+			break
+		}
+
+		wg.Add(1)
+		go func(parent *Content) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			replies, err := api.GetContentReplies(parent.Author, parent.Permlink)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = errors.Wrapf(err,
+						"GetDiscussionTree: failed to get replies to @%v/%v", parent.Author, parent.Permlink)
+				}
+				return
+			}
+			parent.Replies = replies
+		}(parent)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+func contentKey(content *Content) string {
+	return content.Author + "/" + content.Permlink
+}
@@ -0,0 +1,185 @@
+package database
+
+import (
+	// Stdlib
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// contentTree serves get_content and get_content_replies from a map of replies.
+type contentTree struct {
+	replies map[string][]string // permlink -> reply permlinks
+	fail    map[string]bool     // permlinks get_content_replies fails for
+	delay   time.Duration
+
+	calls    int32
+	inFlight int32
+	maxCalls int32
+
+	mu     sync.Mutex
+	called []string
+}
+
+func (tree *contentTree) caller() callerFunc {
+	return func(method string, params interface{}) (interface{}, error) {
+		permlink := params.([]string)[1]
+		switch method {
+		case "get_content":
+			return &Content{Author: "alice", Permlink: permlink}, nil
+
+		case "get_content_replies":
+			atomic.AddInt32(&tree.calls, 1)
+			current := atomic.AddInt32(&tree.inFlight, 1)
+			defer atomic.AddInt32(&tree.inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&tree.maxCalls)
+				if current <= max || atomic.CompareAndSwapInt32(&tree.maxCalls, max, current) {
+					break
+				}
+			}
+
+			tree.mu.Lock()
+			tree.called = append(tree.called, permlink)
+			tree.mu.Unlock()
+
+			time.Sleep(tree.delay)
+			if tree.fail[permlink] {
+				return nil, errors.New("connection lost")
+			}
+
+			var replies []*Content
+			for _, reply := range tree.replies[permlink] {
+				replies = append(replies, &Content{Author: "alice", Permlink: reply})
+			}
+			return replies, nil
+		}
+		return nil, errors.New("unexpected method: " + method)
+	}
+}
+
+// permlinks returns the permlinks of the tree in breadth-first order.
+func permlinks(root *Content) []string {
+	var out []string
+	level := []*Content{root}
+	for len(level) != 0 {
+		var next []*Content
+		for _, content := range level {
+			out = append(out, content.Permlink)
+			next = append(next, content.Replies...)
+		}
+		level = next
+	}
+	return out
+}
+
+func TestGetDiscussionTree(t *testing.T) {
+	tree := &contentTree{replies: map[string][]string{
+		"root": {"a", "b"},
+		"a":    {"a1", "a2"},
+		"a1":   {"a11"},
+	}}
+
+	root, err := NewAPI(tree.caller()).GetDiscussionTree("alice", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"root", "a", "b", "a1", "a2", "a11"}
+	if got := permlinks(root); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestGetDiscussionTree_Cycle(t *testing.T) {
+	// A misbehaving node returning ancestors and siblings as replies.
+	tree := &contentTree{replies: map[string][]string{
+		"root": {"a", "b"},
+		"a":    {"root", "b", "a1"},
+		"b":    {"a"},
+		"a1":   {"a1", "root"},
+	}}
+
+	root, err := NewAPI(tree.caller()).GetDiscussionTree("alice", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"root", "a", "b", "a1"}
+	if got := permlinks(root); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// Every discussion is asked for its replies exactly once.
+	sort.Strings(tree.called)
+	if expected := []string{"a", "a1", "b", "root"}; !reflect.DeepEqual(tree.called, expected) {
+		t.Errorf("expected calls for %v, got %v", expected, tree.called)
+	}
+}
+
+func TestGetDiscussionTree_MaxDepth(t *testing.T) {
+	tree := &contentTree{replies: map[string][]string{
+		"root": {"a"},
+		"a":    {"a1"},
+		"a1":   {"a11"},
+	}}
+
+	for depth, expected := range map[int][]string{
+		1: {"root", "a"},
+		2: {"root", "a", "a1"},
+		0: {"root", "a", "a1", "a11"},
+	} {
+		root, err := NewAPI(tree.caller()).GetDiscussionTree("alice", "root", SetTreeMaxDepth(depth))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := permlinks(root); !reflect.DeepEqual(got, expected) {
+			t.Errorf("depth %v: expected %v, got %v", depth, expected, got)
+		}
+	}
+}
+
+func TestGetDiscussionTree_Concurrency(t *testing.T) {
+	replies := make([]string, 20)
+	for i := range replies {
+		replies[i] = string(rune('a' + i))
+	}
+	tree := &contentTree{
+		replies: map[string][]string{"root": replies},
+		delay:   5 * time.Millisecond,
+	}
+
+	if _, err := NewAPI(tree.caller()).GetDiscussionTree("alice", "root", SetTreeConcurrency(3)); err != nil {
+		t.Fatal(err)
+	}
+	if tree.maxCalls > 3 {
+		t.Errorf("expected at most 3 calls in flight, got %v", tree.maxCalls)
+	}
+	if tree.calls != 21 {
+		t.Errorf("expected 21 calls, got %v", tree.calls)
+	}
+}
+
+func TestGetDiscussionTree_Error(t *testing.T) {
+	replies := make([]string, 20)
+	for i := range replies {
+		replies[i] = string(rune('a' + i))
+	}
+	tree := &contentTree{
+		replies: map[string][]string{"root": replies},
+		fail:    map[string]bool{"a": true},
+	}
+
+	if _, err := NewAPI(tree.caller()).GetDiscussionTree("alice", "root", SetTreeConcurrency(1)); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	// The call for root and the failing call, no call is started after the failure.
+	if tree.calls != 2 {
+		t.Errorf("expected 2 calls, got %v", tree.calls)
+	}
+}
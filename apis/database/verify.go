@@ -0,0 +1,114 @@
+package database
+
+import (
+	// Stdlib
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+	"github.com/goscorum/scorumgo/types"
+
+	// Vendor
+	"github.com/asuleymanov/btc/btcd/btcec"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+)
+
+var (
+	ErrMerkleRootMismatch = errors.New("transaction merkle root mismatch")
+	ErrSignatureMismatch  = errors.New("block not signed by the witness signing key")
+)
+
+// VerifyBlock checks that the block has not been tampered with.
+//
+// The transaction merkle root is recomputed from the serialized transactions
+// and the signer is recovered from the witness signature over the block header.
+// witnessSigningKey is the signing key of the witness as stored on chain,
+// it must be obtained from a source trusted more than the block itself.
+// The key prefix is not checked, only the key itself.
+// The options are passed into the transaction encoder, e.g. transaction.SetCatalog.
+func VerifyBlock(block *Block, witnessSigningKey *types.PublicKey, options ...transaction.Option) error {
+	merkleRoot, err := block.MerkleRoot(options...)
+	if err != nil {
+		return err
+	}
+	if merkleRoot != block.TransactionMerkleRoot {
+		return errors.Wrapf(ErrMerkleRootMismatch,
+			"expected %v, got %v", merkleRoot, block.TransactionMerkleRoot)
+	}
+
+	if witnessSigningKey == nil {
+		return errors.New("witness signing key not set")
+	}
+	signingKey, err := block.RecoverSigningKey()
+	if err != nil {
+		return err
+	}
+	if !signingKey.Equal(witnessSigningKey) {
+		return errors.Wrapf(ErrSignatureMismatch, "witness %v, key %v", block.Witness, witnessSigningKey)
+	}
+	return nil
+}
+
+// MerkleRoot computes the transaction merkle root of the block.
+//
+// The leaves are sha256 digests of the serialized signed transactions,
+// the inner nodes are sha256 digests of the concatenated children
+// and the root is ripemd160 of the top-level node.
+// A block with no transactions has a zero merkle root.
+// The options are passed into the transaction encoder, e.g. transaction.SetCatalog.
+func (block *Block) MerkleRoot(options ...transaction.Option) (string, error) {
+	if len(block.Transactions) == 0 {
+		return hex.EncodeToString(make([]byte, ripemd160.Size)), nil
+	}
+
+	digests := make([][]byte, 0, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		var b bytes.Buffer
+		if err := tx.MarshalSignedTransaction(transaction.NewEncoder(&b, options...)); err != nil {
+			return "", errors.Wrapf(err, "failed to serialize transaction %v", i)
+		}
+		digest := sha256.Sum256(b.Bytes())
+		digests = append(digests, digest[:])
+	}
+
+	for len(digests) > 1 {
+		next := make([][]byte, 0, (len(digests)+1)/2)
+		for i := 0; i+1 < len(digests); i += 2 {
+			digest := sha256.Sum256(append(append([]byte{}, digests[i]...), digests[i+1]...))
+			next = append(next, digest[:])
+		}
+		// An odd node is carried to the next level as it is.
+		if len(digests)%2 == 1 {
+			next = append(next, digests[len(digests)-1])
+		}
+		digests = next
+	}
+
+	hash := ripemd160.New()
+	hash.Write(digests[0])
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RecoverSigningKey recovers the public key the block was signed with
+// from the witness signature. The key is formatted with types.DefaultAddressPrefix.
+func (block *Block) RecoverSigningKey() (*types.PublicKey, error) {
+	var b bytes.Buffer
+	if err := block.encodeHeader(transaction.NewEncoder(&b)); err != nil {
+		return nil, err
+	}
+	digest := sha256.Sum256(b.Bytes())
+
+	signature, err := hex.DecodeString(block.WitnessSignature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode witness signature: %v", block.WitnessSignature)
+	}
+
+	key, _, err := btcec.RecoverCompact(btcec.S256(), signature, digest[:])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to recover signing key from witness signature")
+	}
+	return types.NewPublicKey(key.SerializeCompressed(), types.DefaultAddressPrefix)
+}
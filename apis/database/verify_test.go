@@ -0,0 +1,116 @@
+package database
+
+import (
+	// Stdlib
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/types"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// The key block 1 of the Steem blockchain was signed with.
+var initminerKey = types.MustParsePublicKey("STM8GC13uCZbP44HzMLV6zPZGwVQ8Nt4Kji8PapsPiNq1BK153XTX", "STM")
+
+func TestVerifyBlock(t *testing.T) {
+	var block Block
+	if err := json.Unmarshal([]byte(block1JSON), &block); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyBlock(&block, initminerKey); err != nil {
+		t.Error(err)
+	}
+
+	// The prefix is not part of the key.
+	if err := VerifyBlock(&block, initminerKey.WithPrefix(types.DefaultAddressPrefix)); err != nil {
+		t.Error(err)
+	}
+
+	block.Witness = "mallory"
+	if err := VerifyBlock(&block, initminerKey); errors.Cause(err) != ErrSignatureMismatch {
+		t.Errorf("expected %v, got %v", ErrSignatureMismatch, err)
+	}
+}
+
+func TestVerifyBlock_MerkleRoot(t *testing.T) {
+	var block Block
+	if err := json.Unmarshal([]byte(block1JSON), &block); err != nil {
+		t.Fatal(err)
+	}
+	block.Transactions = []*types.Transaction{voteTransaction(10000)}
+
+	if err := VerifyBlock(&block, initminerKey); errors.Cause(err) != ErrMerkleRootMismatch {
+		t.Errorf("expected %v, got %v", ErrMerkleRootMismatch, err)
+	}
+}
+
+func TestVerifyBlock_Extensions(t *testing.T) {
+	var block Block
+	if err := json.Unmarshal([]byte(block1JSON), &block); err != nil {
+		t.Fatal(err)
+	}
+
+	// The extensions are signed as a part of the header.
+	block.Extensions = types.BlockHeaderExtensions{&types.Version{Hardfork: 19}}
+	if err := VerifyBlock(&block, initminerKey); errors.Cause(err) != ErrSignatureMismatch {
+		t.Errorf("expected %v, got %v", ErrSignatureMismatch, err)
+	}
+}
+
+func TestBlock_MerkleRoot(t *testing.T) {
+	// The leaves are the piston vote transaction from the reference library
+	// with different weights and a dummy signature, an odd number of them,
+	// so that a node is carried over. The root was computed independently
+	// from the raw bytes following signed_block::calculate_merkle_root.
+	expected := "d1e98cbce63e4ee357a28ca727f6216870803030"
+
+	var block Block
+	for _, weight := range []string{"1027", "8813", "f0d8"} {
+		tx, err := types.DecodeTransactionHex("bd8c5fe26f45f179a8570100057865726f63057865726f6306706973746f6e" +
+			weight + "00" + "01" + "1f" + strings.Repeat("11", 64))
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	got, err := block.MerkleRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	block.Transactions = nil
+	got, err = block.MerkleRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != strings.Repeat("0", 40) {
+		t.Errorf("expected zero merkle root, got %v", got)
+	}
+}
+
+func voteTransaction(weight types.Int16) *types.Transaction {
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := &types.Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &types.Time{Time: &expiration},
+		Signatures:     []string{"1f" + strings.Repeat("11", 64)},
+	}
+	tx.PushOperation(&types.VoteOperation{
+		Voter:    "xeroc",
+		Author:   "xeroc",
+		Permlink: "piston",
+		Weight:   weight,
+	})
+	return tx
+}
@@ -157,6 +157,25 @@ func (api *API) GetFeedRaw(
 	return &resp, nil
 }
 
+func (api *API) GetFeed(
+	accountName string,
+	entryID uint32,
+	limit uint16,
+) ([]*CommentFeedEntry, error) {
+
+	raw, err := api.GetFeedRaw(accountName, entryID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*CommentFeedEntry
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_feed response")
+	}
+	return resp, nil
+}
+
 func (api *API) GetAccountReputationsRaw(
 	lowerBoundName string,
 	limit uint32,
@@ -175,6 +194,24 @@ func (api *API) GetAccountReputationsRaw(
 	return &resp, nil
 }
 
+func (api *API) GetAccountReputations(
+	lowerBoundName string,
+	limit uint32,
+) ([]*AccountReputation, error) {
+
+	raw, err := api.GetAccountReputationsRaw(lowerBoundName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*AccountReputation
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_account_reputations response")
+	}
+	return resp, nil
+}
+
 func (api *API) GetFollowCountRaw(
 	accountName string,
 ) (*json.RawMessage, error) {
@@ -203,3 +240,137 @@ func (api *API) GetFollowCount(
 	}
 	return resp, nil
 }
+
+func (api *API) GetBlogRaw(
+	accountName string,
+	entryID uint32,
+	limit uint16,
+) (*json.RawMessage, error) {
+
+	if limit == 0 {
+		limit = 500
+	}
+
+	var resp json.RawMessage
+	params := []interface{}{accountName, entryID, limit}
+	if err := api.call("get_blog", params, &resp); err != nil {
+		return nil, errors.Wrap(err, "goscorum/scorumgo: follow_api: failed to call get_blog")
+	}
+	return &resp, nil
+}
+
+func (api *API) GetBlog(
+	accountName string,
+	entryID uint32,
+	limit uint16,
+) ([]*CommentBlogEntry, error) {
+
+	raw, err := api.GetBlogRaw(accountName, entryID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*CommentBlogEntry
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_blog response")
+	}
+	return resp, nil
+}
+
+func (api *API) GetBlogEntriesRaw(
+	accountName string,
+	entryID uint32,
+	limit uint16,
+) (*json.RawMessage, error) {
+
+	if limit == 0 {
+		limit = 500
+	}
+
+	var resp json.RawMessage
+	params := []interface{}{accountName, entryID, limit}
+	if err := api.call("get_blog_entries", params, &resp); err != nil {
+		return nil, errors.Wrap(err, "goscorum/scorumgo: follow_api: failed to call get_blog_entries")
+	}
+	return &resp, nil
+}
+
+func (api *API) GetBlogEntries(
+	accountName string,
+	entryID uint32,
+	limit uint16,
+) ([]*BlogEntry, error) {
+
+	raw, err := api.GetBlogEntriesRaw(accountName, entryID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*BlogEntry
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_blog_entries response")
+	}
+	return resp, nil
+}
+
+func (api *API) GetRebloggedByRaw(
+	author string,
+	permlink string,
+) (*json.RawMessage, error) {
+
+	var resp json.RawMessage
+	params := []interface{}{author, permlink}
+	if err := api.call("get_reblogged_by", params, &resp); err != nil {
+		return nil, errors.Wrap(err, "goscorum/scorumgo: follow_api: failed to call get_reblogged_by")
+	}
+	return &resp, nil
+}
+
+func (api *API) GetRebloggedBy(
+	author string,
+	permlink string,
+) ([]string, error) {
+
+	raw, err := api.GetRebloggedByRaw(author, permlink)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []string
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_reblogged_by response")
+	}
+	return resp, nil
+}
+
+func (api *API) GetBlogAuthorsRaw(
+	blogAccount string,
+) (*json.RawMessage, error) {
+
+	var resp json.RawMessage
+	params := []interface{}{blogAccount}
+	if err := api.call("get_blog_authors", params, &resp); err != nil {
+		return nil, errors.Wrap(err, "goscorum/scorumgo: follow_api: failed to call get_blog_authors")
+	}
+	return &resp, nil
+}
+
+func (api *API) GetBlogAuthors(
+	blogAccount string,
+) ([]*BlogAuthor, error) {
+
+	raw, err := api.GetBlogAuthorsRaw(blogAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp []*BlogAuthor
+	if err := json.Unmarshal([]byte(*raw), &resp); err != nil {
+		return nil, errors.Wrap(
+			err, "goscorum/scorumgo: follow_api: failed to unmarshal get_blog_authors response")
+	}
+	return resp, nil
+}
@@ -0,0 +1,30 @@
+package follow
+
+import (
+	// Stdlib
+	"encoding/json"
+)
+
+// callerFunc adapts a function to interfaces.Caller. The function receives
+// the follow_api method and its params, the value it returns is passed
+// to the response through JSON, like a node response.
+type callerFunc func(method string, params []interface{}) (interface{}, error)
+
+func (f callerFunc) Call(method string, params, response interface{}) error {
+	// Requests are sent as call("follow_api", method, params).
+	args := params.([]interface{})
+	result, err := f(args[1].(string), args[2].([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+func newTestAPI(f callerFunc) *API {
+	return &API{caller: f}
+}
@@ -1,5 +1,17 @@
 package follow
 
+import (
+	// Stdlib
+	"encoding/json"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/types"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
 const (
 	FollowKindFollow = "blog"
 	FollowKindIgnore = "ignore"
@@ -12,24 +24,65 @@ type FollowObject struct {
 }
 
 type FeedEntry struct {
-	Author   string `json:"string"`
-	Permlink string `json:"permlink"`
-	EntryID  uint32 `json:"entry_id"`
+	Author   string      `json:"author"`
+	Permlink string      `json:"permlink"`
+	ReblogBy []string    `json:"reblog_by"`
+	ReblogOn *types.Time `json:"reblog_on"`
+	EntryID  uint32      `json:"entry_id"`
 }
 
-/*
 type CommentFeedEntry struct {
-	Comment *CommentObject `json:"comment"`
-	EntryID uint32         `json:"entry_id"`
+	Comment  *database.Content `json:"comment"`
+	ReblogBy []string          `json:"reblog_by"`
+	ReblogOn *types.Time       `json:"reblog_on"`
+	EntryID  uint32            `json:"entry_id"`
+}
+
+type BlogEntry struct {
+	Author   string      `json:"author"`
+	Permlink string      `json:"permlink"`
+	Blog     string      `json:"blog"`
+	ReblogOn *types.Time `json:"reblog_on"`
+	EntryID  uint32      `json:"entry_id"`
+}
+
+type CommentBlogEntry struct {
+	Comment  *database.Content `json:"comment"`
+	Blog     string            `json:"blog"`
+	ReblogOn *types.Time       `json:"reblog_on"`
+	EntryID  uint32            `json:"entry_id"`
 }
-*/
 
-/*
 type AccountReputation struct {
-	Account string `json:"account"`
-	Reputation ??? `json:"reputation"`
+	Account    string     `json:"account"`
+	Reputation *types.Int `json:"reputation"`
+}
+
+// BlogAuthor represents an author whose posts appear in a blog,
+// together with the number of such posts.
+type BlogAuthor struct {
+	Author string
+	Count  uint32
+}
+
+func (author *BlogAuthor) UnmarshalJSON(data []byte) error {
+	// The blog author object is [author, count].
+	raw := make([]json.RawMessage, 2)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return errors.Errorf("invalid blog author object: %v", string(data))
+	}
+
+	if err := json.Unmarshal(raw[0], &author.Author); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal BlogAuthor.Author: %v", string(raw[0]))
+	}
+	if err := json.Unmarshal(raw[1], &author.Count); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal BlogAuthor.Count: %v", string(raw[1]))
+	}
+	return nil
 }
-*/
 
 type FollowCount struct {
 	Account        string `json:"account"`
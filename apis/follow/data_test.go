@@ -0,0 +1,131 @@
+package follow
+
+import (
+	// Stdlib
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// fixtureAPI returns an API answering the given method with the given JSON.
+func fixtureAPI(t *testing.T, expectedMethod, response string) *API {
+	return newTestAPI(func(method string, params []interface{}) (interface{}, error) {
+		if method != expectedMethod {
+			t.Fatalf("expected %v, got %v", expectedMethod, method)
+		}
+		return json.RawMessage(response), nil
+	})
+}
+
+func TestBlogAuthor_UnmarshalJSON(t *testing.T) {
+	api := fixtureAPI(t, "get_blog_authors", `[["alice", 12], ["bob", 1]]`)
+
+	authors, err := api.GetBlogAuthors("carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*BlogAuthor{{Author: "alice", Count: 12}, {Author: "bob", Count: 1}}
+	if !reflect.DeepEqual(authors, expected) {
+		t.Errorf("expected %+v, got %+v", expected, authors)
+	}
+
+	for _, data := range []string{`["alice"]`, `["alice", 1, 2]`, `[1, 2]`, `["alice", "many"]`, `{"alice": 1}`} {
+		var author BlogAuthor
+		if err := json.Unmarshal([]byte(data), &author); err == nil {
+			t.Errorf("%v: expected an error", data)
+		}
+	}
+}
+
+func TestAPI_GetAccountReputations(t *testing.T) {
+	api := fixtureAPI(t, "get_account_reputations", `[
+		{"account": "alice", "reputation": "95832978796820"},
+		{"account": "bob", "reputation": -1234567},
+		{"account": "carol", "reputation": 0}
+	]`)
+
+	reputations, err := api.GetAccountReputations("alice", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]int64{"alice": 95832978796820, "bob": -1234567, "carol": 0}
+	if len(reputations) != len(expected) {
+		t.Fatalf("unexpected reputations: %+v", reputations)
+	}
+	for _, reputation := range reputations {
+		if got := reputation.Reputation.Int64(); got != expected[reputation.Account] {
+			t.Errorf("%v: expected %v, got %v", reputation.Account, expected[reputation.Account], got)
+		}
+	}
+}
+
+func TestAPI_GetBlog(t *testing.T) {
+	api := fixtureAPI(t, "get_blog", `[{
+		"comment": {"author": "bob", "permlink": "hello", "title": "Hello"},
+		"blog": "alice",
+		"reblog_on": "2018-03-02T14:13:20",
+		"entry_id": 7
+	}]`)
+
+	entries, err := api.GetBlog("alice", 7, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Comment.Author != "bob" || entry.Blog != "alice" || entry.EntryID != 7 ||
+		entry.ReblogOn.Format("2006-01-02T15:04:05") != "2018-03-02T14:13:20" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestAPI_GetFeed(t *testing.T) {
+	api := fixtureAPI(t, "get_feed", `[{
+		"comment": {"author": "bob", "permlink": "hello"},
+		"reblog_by": ["carol"],
+		"reblog_on": "2018-03-02T14:13:20",
+		"entry_id": 3
+	}]`)
+
+	entries, err := api.GetFeed("alice", 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Comment.Permlink != "hello" ||
+		!reflect.DeepEqual(entries[0].ReblogBy, []string{"carol"}) || entries[0].EntryID != 3 {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestAPI_GetBlogEntries(t *testing.T) {
+	api := fixtureAPI(t, "get_blog_entries", `[{
+		"author": "bob",
+		"permlink": "hello",
+		"blog": "alice",
+		"reblog_on": "1970-01-01T00:00:00",
+		"entry_id": 0
+	}]`)
+
+	entries, err := api.GetBlogEntries("alice", 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Author != "bob" || entries[0].Permlink != "hello" || entries[0].Blog != "alice" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestAPI_GetRebloggedBy(t *testing.T) {
+	api := fixtureAPI(t, "get_reblogged_by", `["bob", "alice"]`)
+
+	accounts, err := api.GetRebloggedBy("bob", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(accounts, []string{"bob", "alice"}) {
+		t.Errorf("unexpected accounts: %v", accounts)
+	}
+}
@@ -0,0 +1,136 @@
+package follow
+
+import (
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// FollowIterator pages through get_followers or get_following.
+//
+// Every page after the first one starts at the last account
+// of the previous page, the iterator takes care of dropping the duplicate.
+type FollowIterator struct {
+	fetch func(start string) ([]*FollowObject, error)
+	key   func(obj *FollowObject) string
+	limit uint16
+
+	start   string
+	page    []*FollowObject
+	current *FollowObject
+	first   bool
+	done    bool
+	err     error
+}
+
+// IterateFollowers returns an iterator over all followers of the given account,
+// fetching limit followers per call.
+func (api *API) IterateFollowers(accountName, start, kind string, limit uint16) *FollowIterator {
+	return newFollowIterator(
+		func(start string) ([]*FollowObject, error) {
+			return api.GetFollowers(accountName, start, kind, limit)
+		},
+		func(obj *FollowObject) string {
+			return obj.Follower
+		},
+		start,
+		limit,
+	)
+}
+
+// IterateFollowing returns an iterator over all accounts followed by the given account,
+// fetching limit accounts per call.
+func (api *API) IterateFollowing(accountName, start, kind string, limit uint16) *FollowIterator {
+	return newFollowIterator(
+		func(start string) ([]*FollowObject, error) {
+			return api.GetFollowing(accountName, start, kind, limit)
+		},
+		func(obj *FollowObject) string {
+			return obj.Following
+		},
+		start,
+		limit,
+	)
+}
+
+func newFollowIterator(
+	fetch func(start string) ([]*FollowObject, error),
+	key func(obj *FollowObject) string,
+	start string,
+	limit uint16,
+) *FollowIterator {
+
+	it := &FollowIterator{
+		fetch: fetch,
+		key:   key,
+		limit: limit,
+		start: start,
+		first: true,
+	}
+	if limit < 2 {
+		it.err = errors.New("FollowIterator: limit must be at least 2")
+	}
+	return it
+}
+
+// Next advances the iterator to the next follow object.
+// It returns false when there are no more objects or an error occurred.
+func (it *FollowIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.load(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// FollowObject returns the object the iterator is currently pointing at.
+func (it *FollowIterator) FollowObject() *FollowObject {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *FollowIterator) Err() error {
+	return it.err
+}
+
+func (it *FollowIterator) load() error {
+	page, err := it.fetch(it.start)
+	if err != nil {
+		return err
+	}
+
+	if len(page) < int(it.limit) {
+		it.done = true
+	}
+	if len(page) == 0 {
+		it.done = true
+		return nil
+	}
+
+	last := it.key(page[len(page)-1])
+	if !it.first && it.key(page[0]) == it.start {
+		page = page[1:]
+	}
+	it.first = false
+	it.start = last
+
+	if len(page) == 0 {
+		it.done = true
+	}
+
+	it.page = page
+	return nil
+}
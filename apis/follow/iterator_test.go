@@ -0,0 +1,89 @@
+package follow
+
+import (
+	// Stdlib
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFollowIterator(t *testing.T) {
+	followers := func(n int) []string {
+		names := make([]string, n)
+		for i := range names {
+			names[i] = fmt.Sprintf("follower-%02d", i)
+		}
+		return names
+	}
+
+	tests := []struct {
+		name      string
+		followers []string
+		fail      int
+		calls     int
+		err       bool
+	}{
+		{name: "empty first page", followers: nil, calls: 1},
+		{name: "short first page", followers: followers(3), calls: 1},
+		{name: "exactly limit results", followers: followers(5), calls: 2},
+		{name: "page with only the cursor item", followers: followers(9), calls: 3},
+		{name: "error on second page", followers: followers(12), fail: 2, calls: 2, err: true},
+	}
+
+	const limit = 5
+	for _, test := range tests {
+		calls := 0
+		api := newTestAPI(func(method string, params []interface{}) (interface{}, error) {
+			calls++
+			if method != "get_followers" || params[0] != "alice" || params[2] != "blog" {
+				t.Fatalf("unexpected call: %v %v", method, params)
+			}
+			if calls == test.fail {
+				return nil, errors.New("connection lost")
+			}
+
+			// The start is inclusive.
+			from, start := 0, params[1].(string)
+			for start != "" && from < len(test.followers) && test.followers[from] != start {
+				from++
+			}
+			to := from + int(params[3].(uint16))
+			if to > len(test.followers) {
+				to = len(test.followers)
+			}
+
+			var page []*FollowObject
+			for _, follower := range test.followers[from:to] {
+				page = append(page, &FollowObject{Follower: follower, Following: "alice"})
+			}
+			return page, nil
+		})
+
+		var got []string
+		it := api.IterateFollowers("alice", "", FollowKindFollow, limit)
+		for it.Next() {
+			got = append(got, it.FollowObject().Follower)
+		}
+
+		if test.err {
+			if it.Err() == nil {
+				t.Errorf("%v: expected an error", test.name)
+			}
+		} else {
+			if err := it.Err(); err != nil {
+				t.Errorf("%v: unexpected error: %v", test.name, err)
+			}
+			if !reflect.DeepEqual(got, test.followers) {
+				t.Errorf("%v: expected %v, got %v", test.name, test.followers, got)
+			}
+		}
+		if calls != test.calls {
+			t.Errorf("%v: expected %v calls, got %v", test.name, test.calls, calls)
+		}
+	}
+
+	if it := newTestAPI(nil).IterateFollowing("alice", "", FollowKindFollow, 1); it.Next() || it.Err() == nil {
+		t.Error("expected an error for a limit below 2")
+	}
+}
@@ -0,0 +1,172 @@
+package transaction
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// Decoder reads values in the format written by Encoder.
+type Decoder struct {
+	r    io.Reader
+	opts options
+}
+
+func NewDecoder(r io.Reader, options ...Option) *Decoder {
+	return &Decoder{r, newOptions(options)}
+}
+
+// Catalog returns the catalog set using SetCatalog, nil by default.
+func (decoder *Decoder) Catalog() Catalog {
+	return decoder.opts.catalog
+}
+
+// ReadByte implements io.ByteReader so that varints can be read directly.
+func (decoder *Decoder) ReadByte() (byte, error) {
+	var b [1]byte
+	if _, err := io.ReadFull(decoder.r, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (decoder *Decoder) DecodeVarint() (int64, error) {
+	i, err := binary.ReadVarint(decoder)
+	if err != nil {
+		return 0, errors.Wrap(err, "decoder: failed to read varint")
+	}
+	return i, nil
+}
+
+func (decoder *Decoder) DecodeUVarint() (uint64, error) {
+	i, err := binary.ReadUvarint(decoder)
+	if err != nil {
+		return 0, errors.Wrap(err, "decoder: failed to read uvarint")
+	}
+	return i, nil
+}
+
+// DecodeNumber reads a fixed-size number, v must be a pointer, e.g. *uint32.
+func (decoder *Decoder) DecodeNumber(v interface{}) error {
+	if err := binary.Read(decoder.r, binary.LittleEndian, v); err != nil {
+		return errors.Wrapf(err, "decoder: failed to read number: %T", v)
+	}
+	return nil
+}
+
+func (decoder *Decoder) DecodeBool() (bool, error) {
+	var b byte
+	if err := decoder.DecodeNumber(&b); err != nil {
+		return false, err
+	}
+	switch b {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, errors.Errorf("decoder: invalid bool value: %v", b)
+	}
+}
+
+func (decoder *Decoder) DecodeString() (string, error) {
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return "", errors.Wrap(err, "decoder: failed to read string length")
+	}
+
+	b, err := decoder.readBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (decoder *Decoder) DecodeArrString() ([]string, error) {
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "decoder: failed to read array length")
+	}
+
+	var v []string
+	for i := uint64(0); i < length; i++ {
+		s, err := decoder.DecodeString()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, s)
+	}
+	return v, nil
+}
+
+// DecodeBytes reads a length-prefixed byte array, i.e. vector<char>.
+func (decoder *Decoder) DecodeBytes() ([]byte, error) {
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "decoder: failed to read bytes length")
+	}
+
+	return decoder.readBytes(length)
+}
+
+func (decoder *Decoder) readBytes(length uint64) ([]byte, error) {
+	if length > math.MaxInt32 {
+		return nil, errors.Errorf("decoder: length out of range: %v", length)
+	}
+
+	// Not allocating the whole length in advance, it may be garbage.
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, decoder.r, int64(length)); err != nil {
+		return nil, errors.Wrapf(err, "decoder: failed to read %v bytes", length)
+	}
+	return b.Bytes(), nil
+}
+
+// DecodeRaw reads exactly len(bs) bytes, without any length prefix.
+// This is how fixed-size fields such as hashes and signatures are serialized.
+func (decoder *Decoder) DecodeRaw(bs []byte) error {
+	if _, err := io.ReadFull(decoder.r, bs); err != nil {
+		return errors.Wrapf(err, "decoder: failed to read %v bytes", len(bs))
+	}
+	return nil
+}
+
+// Decode reads a value into v, which must be a pointer.
+//
+// Values implementing TransactionUnmarshaller decode themselves,
+// otherwise pointers to numbers, strings and bools are supported.
+func (decoder *Decoder) Decode(v interface{}) error {
+	if unmarshaller, ok := v.(TransactionUnmarshaller); ok {
+		return unmarshaller.UnmarshalTransaction(decoder)
+	}
+
+	switch v := v.(type) {
+	case *int8, *int16, *int32, *int64,
+		*uint8, *uint16, *uint32, *uint64:
+		return decoder.DecodeNumber(v)
+
+	case *string:
+		s, err := decoder.DecodeString()
+		if err != nil {
+			return err
+		}
+		*v = s
+		return nil
+
+	case *bool:
+		b, err := decoder.DecodeBool()
+		if err != nil {
+			return err
+		}
+		*v = b
+		return nil
+
+	default:
+		return errors.Errorf("decoder: unsupported type encountered: %T", v)
+	}
+}
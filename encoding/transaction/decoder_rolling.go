@@ -0,0 +1,65 @@
+package transaction
+
+// RollingDecoder is the decoding counterpart of RollingEncoder.
+// Once an error occurs, all the following calls are no-ops
+// and the error is returned by Err.
+type RollingDecoder struct {
+	next *Decoder
+	err  error
+}
+
+func NewRollingDecoder(next *Decoder) *RollingDecoder {
+	return &RollingDecoder{next, nil}
+}
+
+func (decoder *RollingDecoder) DecodeVarint(v *int64) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeVarint()
+	}
+}
+
+func (decoder *RollingDecoder) DecodeUVarint(v *uint64) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeUVarint()
+	}
+}
+
+func (decoder *RollingDecoder) DecodeNumber(v interface{}) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.DecodeNumber(v)
+	}
+}
+
+func (decoder *RollingDecoder) DecodeBool(v *bool) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeBool()
+	}
+}
+
+func (decoder *RollingDecoder) DecodeArrString(v *[]string) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeArrString()
+	}
+}
+
+func (decoder *RollingDecoder) DecodeBytes(v *[]byte) {
+	if decoder.err == nil {
+		*v, decoder.err = decoder.next.DecodeBytes()
+	}
+}
+
+func (decoder *RollingDecoder) DecodeRaw(v []byte) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.DecodeRaw(v)
+	}
+}
+
+func (decoder *RollingDecoder) Decode(v interface{}) {
+	if decoder.err == nil {
+		decoder.err = decoder.next.Decode(v)
+	}
+}
+
+func (decoder *RollingDecoder) Err() error {
+	return decoder.err
+}
@@ -13,11 +13,17 @@ import (
 )
 
 type Encoder struct {
-	w io.Writer
+	w    io.Writer
+	opts options
 }
 
-func NewEncoder(w io.Writer) *Encoder {
-	return &Encoder{w}
+func NewEncoder(w io.Writer, options ...Option) *Encoder {
+	return &Encoder{w, newOptions(options)}
+}
+
+// Catalog returns the catalog set using SetCatalog, nil by default.
+func (encoder *Encoder) Catalog() Catalog {
+	return encoder.opts.catalog
 }
 
 func (encoder *Encoder) EncodeVarint(i int64) error {
@@ -58,6 +64,12 @@ func (encoder *Encoder) EncodeArrString(v []string) error {
 	return nil
 }
 
+// EncodeRaw writes the given bytes as they are, without the length prefix.
+// This is how fixed-size fields such as hashes and signatures are serialized.
+func (encoder *Encoder) EncodeRaw(bs []byte) error {
+	return encoder.writeBytes(bs)
+}
+
 func (encoder *Encoder) Encode(v interface{}) error {
 	if marshaller, ok := v.(TransactionMarshaller); ok {
 		return marshaller.MarshalTransaction(encoder)
@@ -124,36 +136,40 @@ func (encoder *Encoder) EncodeBool(b bool) error {
 	}
 }
 
+var moneyRegexp = regexp.MustCompile("^[0-9]+\\.?[0-9]* [A-Za-z0-9]+$")
+
+// EncodeMoney encodes an asset string such as "10.000000000 SCR".
+//
+// Deprecated: Use types.Asset, it is parsed once and it marshals itself.
 func (encoder *Encoder) EncodeMoney(s string) error {
-	r, _ := regexp.Compile("^[0-9]+\\.?[0-9]* [A-Za-z0-9]+$")
-	if r.MatchString(s) {
-		asset := strings.Split(s, " ")
-		amm, _ := strconv.ParseInt(strings.Replace(asset[0], ".", "", -1), 10, 64)
-		ind := strings.Index(asset[0], ".")
-		var perc int
-		if ind == -1 {
-			perc = 0
-		} else {
-			perc = len(asset[0]) - ind - 1
-		}
-		if err := binary.Write(encoder.w, binary.LittleEndian, amm); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write number: %v", amm)
-		}
-		if err := binary.Write(encoder.w, binary.LittleEndian, byte(perc)); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write number: %v", perc)
-		}
+	if !moneyRegexp.MatchString(s) {
+		return errors.Errorf("encoder: expecting amount like '99.000 SYMBOL', got %q", s)
+	}
 
-		if _, err := io.Copy(encoder.w, strings.NewReader(asset[1])); err != nil {
-			return errors.Wrapf(err, "encoder: failed to write string: %v", asset[1])
-		}
+	asset := strings.Split(s, " ")
+	number, symbol := asset[0], asset[1]
+	if len(symbol) > 7 {
+		return errors.Errorf("encoder: asset symbol too long: %v", symbol)
+	}
 
-		for i := byte(len(asset[1])); i < 7; i++ {
-			if err := binary.Write(encoder.w, binary.LittleEndian, byte(0)); err != nil {
-				return errors.Wrapf(err, "encoder: failed to write number: %v", 0)
-			}
-		}
-		return nil
-	} else {
-		return errors.New("Expecting amount like '99.000 SYMBOL'")
+	amount, err := strconv.ParseInt(strings.Replace(number, ".", "", -1), 10, 64)
+	if err != nil {
+		return errors.Wrapf(err, "encoder: failed to parse amount: %v", s)
+	}
+
+	var precision int
+	if ind := strings.Index(number, "."); ind != -1 {
+		precision = len(number) - ind - 1
+	}
+
+	symbolBytes := make([]byte, 7)
+	copy(symbolBytes, symbol)
+
+	if err := encoder.EncodeNumber(amount); err != nil {
+		return err
+	}
+	if err := encoder.EncodeNumber(byte(precision)); err != nil {
+		return err
 	}
+	return encoder.writeBytes(symbolBytes)
 }
@@ -45,6 +45,12 @@ func (encoder *RollingEncoder) EncodeArrString(v []string) {
 	}
 }
 
+func (encoder *RollingEncoder) EncodeRaw(v []byte) {
+	if encoder.err == nil {
+		encoder.err = encoder.next.EncodeRaw(v)
+	}
+}
+
 func (encoder *RollingEncoder) Encode(v interface{}) {
 	if encoder.err == nil {
 		encoder.err = encoder.next.Encode(v)
@@ -3,3 +3,39 @@ package transaction
 type TransactionMarshaller interface {
 	MarshalTransaction(*Encoder) error
 }
+
+type TransactionUnmarshaller interface {
+	UnmarshalTransaction(*Decoder) error
+}
+
+// Catalog identifies the chain the data is serialized for, e.g. types.ScorumOpCatalog.
+//
+// Chains derived from Steem use different operation codes and layouts,
+// so the values depending on the chain look the catalog up
+// using Encoder.Catalog and Decoder.Catalog.
+type Catalog interface {
+	Name() string
+}
+
+type options struct {
+	catalog Catalog
+}
+
+// Option represents an option that can be passed into NewEncoder and NewDecoder.
+type Option func(*options)
+
+// SetCatalog sets the catalog the data is serialized for.
+// When no catalog is set, the values choose their default, see types.OpCatalog.
+func SetCatalog(catalog Catalog) Option {
+	return func(opts *options) {
+		opts.catalog = catalog
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/goscorum/scorumgo"
+	"github.com/goscorum/scorumgo/stream"
 	"github.com/goscorum/scorumgo/transports/websocket"
 	"github.com/goscorum/scorumgo/types"
 )
@@ -43,18 +45,13 @@ func run() (err error) {
 		}
 	}()
 
-	// Start the connection monitor.
+	// Start the connection and stream monitor.
 	monitorChan := make(chan interface{}, 1)
-	if reconnect {
-		go func() {
-			for {
-				event, ok := <-monitorChan
-				if ok {
-					log.Println(event)
-				}
-			}
-		}()
-	}
+	go func() {
+		for event := range monitorChan {
+			log.Println(event)
+		}
+	}()
 
 	// Instantiate the WebSocket transport.
 	log.Printf("---> Dial(\"%v\")\n", url)
@@ -67,73 +64,41 @@ func run() (err error) {
 	}
 
 	// Use the transport to get an RPC client.
-	client, err := steemgo.NewClient(t)
+	client, err := scorumgo.NewClient(t)
 	if err != nil {
 		return err
 	}
-	defer func() {
-		if !interrupted {
-			client.Close()
-		}
-	}()
+	defer client.Close()
 
 	// Start processing signals.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	go func() {
 		<-signalCh
 		fmt.Println()
 		log.Println("Signal received, exiting...")
 		signal.Stop(signalCh)
 		interrupted = true
-		client.Close()
+		cancel()
 	}()
 
-	// Get config.
-	log.Println("---> GetConfig()")
-	config, err := client.Database.GetConfig()
-	if err != nil {
-		return err
-	}
+	// Stream irreversible blocks starting with the current last irreversible block.
+	streamer := stream.NewStreamer(client.Database, stream.SetMonitor(monitorChan))
 
-	// Use the last irreversible block number as the initial last block number.
-	props, err := client.Database.GetDynamicGlobalProperties()
-	if err != nil {
-		return err
-	}
-	lastBlock := props.LastIrreversibleBlockNum
+	// Keep processing incoming operations until interrupted.
+	log.Println("---> Entering the block processing loop")
+	err = streamer.Run(ctx, stream.Operations(stream.OperationHandlerFunc(func(op *stream.Operation) error {
+		switch body := op.Operation.Data().(type) {
+		case *types.VoteOperation:
+			fmt.Printf("@%v voted for @%v/%v\n", body.Voter, body.Author, body.Permlink)
 
-	// Keep processing incoming blocks forever.
-	log.Printf("---> Entering the block processing loop (last block = %v)\n", lastBlock)
-	for {
-		// Get current properties.
-		props, err := client.Database.GetDynamicGlobalProperties()
-		if err != nil {
-			return err
+			// You can add more cases here, it depends on
+			// what operations you actually need to process.
 		}
-
-		// Process new blocks.
-		for props.LastIrreversibleBlockNum-lastBlock > 0 {
-			block, err := client.Database.GetBlock(lastBlock)
-			if err != nil {
-				return err
-			}
-
-			// Process the transactions.
-			for _, tx := range block.Transactions {
-				for _, operation := range tx.Operations {
-					switch op := operation.Data().(type) {
-					case *types.VoteOperation:
-						fmt.Printf("@%v voted for @%v/%v\n", op.Voter, op.Author, op.Permlink)
-
-						// You can add more cases here, it depends on
-						// what operations you actually need to process.
-					}
-				}
-			}
-
-			lastBlock++
-		}
-
-		// Sleep for STEEMIT_BLOCK_INTERVAL seconds before the next iteration.
-		time.Sleep(time.Duration(config.SteemitBlockInterval) * time.Second)
+		return nil
+	})))
+	if err == context.Canceled && interrupted {
+		err = nil
 	}
+	return err
 }
@@ -0,0 +1,132 @@
+// Package reputation turns the raw reputation values reported by the node
+// into the reputation score displayed by the frontends.
+package reputation
+
+import (
+	// Stdlib
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/apis/follow"
+	"github.com/goscorum/scorumgo/types"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// DefaultScore is the score of an account with no reputation, i.e. a new account.
+const DefaultScore = 25
+
+// Parse turns raw reputation as unmarshalled from the node response into a big integer.
+// Nil is treated as zero reputation.
+func Parse(raw *types.Int) *big.Int {
+	if raw == nil || raw.Int == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(raw.Int)
+}
+
+// ParseString parses raw reputation in the decimal form, e.g. "-2345678901234".
+func ParseString(raw string) (*big.Int, error) {
+	value, ok := new(big.Int).SetString(raw, 10)
+	if !ok {
+		return nil, errors.Errorf("reputation: invalid raw reputation: %v", raw)
+	}
+	return value, nil
+}
+
+// Score converts raw reputation into the reputation score using the log10 formula
+// every frontend is using, i.e. 25 + 9 * (log10(|raw|) - 9), negated for negative values.
+func Score(raw *big.Int) float64 {
+	if raw == nil || raw.Sign() == 0 {
+		return DefaultScore
+	}
+
+	level := log10(new(big.Int).Abs(raw)) - 9
+	if level < 0 {
+		level = 0
+	}
+	if raw.Sign() < 0 {
+		level = -level
+	}
+	return level*9 + DefaultScore
+}
+
+// DisplayScore returns Score rounded down the same way the frontends do it.
+func DisplayScore(raw *big.Int) int {
+	return int(math.Floor(Score(raw)))
+}
+
+// log10 computes log10 of a positive big integer without converting it to float64,
+// which would overflow for very large values.
+func log10(value *big.Int) float64 {
+	digits := value.String()
+
+	// 15 significant digits are more than enough for the score precision.
+	lead := digits
+	if len(lead) > 15 {
+		lead = lead[:15]
+	}
+	mantissa, _ := strconv.ParseFloat(lead, 64)
+	return math.Log10(mantissa) + float64(len(digits)-len(lead))
+}
+
+// Account couples an account name with its raw reputation.
+type Account struct {
+	Name string
+	Raw  *big.Int
+}
+
+// Score returns the account reputation score.
+func (account *Account) Score() float64 {
+	return Score(account.Raw)
+}
+
+// FromAccountReputations collects follow_api get_account_reputations results.
+func FromAccountReputations(reputations []*follow.AccountReputation) []*Account {
+	accounts := make([]*Account, 0, len(reputations))
+	for _, rep := range reputations {
+		accounts = append(accounts, &Account{rep.Account, Parse(rep.Reputation)})
+	}
+	return accounts
+}
+
+// FromExtendedAccounts collects the accounts as returned by database_api get_state.
+func FromExtendedAccounts(extended map[string]*database.ExtendedAccount) []*Account {
+	accounts := make([]*Account, 0, len(extended))
+	for name, account := range extended {
+		accounts = append(accounts, &Account{name, Parse(account.Reputation)})
+	}
+	return accounts
+}
+
+// FromContent returns the reputation of the content author.
+func FromContent(content *database.Content) *Account {
+	return &Account{content.Author, Parse(content.AuthorReputation)}
+}
+
+// Sort sorts the accounts by reputation, highest first.
+// Accounts with the same reputation are sorted by name.
+func Sort(accounts []*Account) {
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if cmp := accounts[i].Raw.Cmp(accounts[j].Raw); cmp != 0 {
+			return cmp > 0
+		}
+		return accounts[i].Name < accounts[j].Name
+	})
+}
+
+// Filter returns the accounts with the reputation score of at least minScore.
+func Filter(accounts []*Account, minScore float64) []*Account {
+	var filtered []*Account
+	for _, account := range accounts {
+		if account.Score() >= minScore {
+			filtered = append(filtered, account)
+		}
+	}
+	return filtered
+}
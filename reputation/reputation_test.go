@@ -0,0 +1,62 @@
+package reputation
+
+import (
+	// Stdlib
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	cases := []struct {
+		raw      string
+		expected float64
+	}{
+		{"0", 25},
+		{"1000", 25},
+		{"1000000000", 25},
+		{"10000000000", 34},
+		{"-10000000000", 16},
+		{"10000000000000000000000000000000000000000", 304},
+	}
+
+	for _, c := range cases {
+		raw, err := ParseString(c.raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := Score(raw); math.Abs(got-c.expected) > 1e-9 {
+			t.Errorf("Score(%v): expected %v, got %v", c.raw, c.expected, got)
+		}
+	}
+}
+
+func TestDisplayScore(t *testing.T) {
+	if got := DisplayScore(big.NewInt(12944616889)); got != 35 {
+		t.Errorf("expected 35, got %v", got)
+	}
+}
+
+func TestSortAndFilter(t *testing.T) {
+	accounts := []*Account{
+		{"alice", big.NewInt(10000000000)},
+		{"bob", big.NewInt(-10000000000)},
+		{"carol", big.NewInt(100000000000)},
+		{"dave", big.NewInt(0)},
+	}
+
+	Sort(accounts)
+
+	expected := []string{"carol", "alice", "dave", "bob"}
+	for i, account := range accounts {
+		if account.Name != expected[i] {
+			t.Errorf("position %v: expected %v, got %v", i, expected[i], account.Name)
+		}
+	}
+
+	filtered := Filter(accounts, DefaultScore)
+	if len(filtered) != 3 {
+		t.Errorf("expected 3 accounts, got %v", len(filtered))
+	}
+}
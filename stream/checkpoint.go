@@ -0,0 +1,66 @@
+package stream
+
+import (
+	// Stdlib
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// Checkpoint keeps track of the last block processed by a streamer,
+// so that the streamer can resume where it stopped.
+type Checkpoint interface {
+	// Load returns the number of the last block processed.
+	// It returns false in case there is no checkpoint stored yet.
+	Load() (uint32, bool, error)
+
+	// Save stores the number of the last block processed.
+	Save(blockNum uint32) error
+}
+
+// FileCheckpoint is a Checkpoint storing the block number in a file.
+type FileCheckpoint struct {
+	path string
+}
+
+// NewFileCheckpoint returns a checkpoint stored in the file at the given path.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path}
+}
+
+// Load implements Checkpoint.
+func (cp *FileCheckpoint) Load() (uint32, bool, error) {
+	content, err := ioutil.ReadFile(cp.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrapf(err, "failed to read checkpoint file: %v", cp.path)
+	}
+
+	blockNum, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 32)
+	if err != nil {
+		return 0, false, errors.Wrapf(err, "invalid checkpoint file: %v", cp.path)
+	}
+	return uint32(blockNum), true, nil
+}
+
+// Save implements Checkpoint.
+//
+// The block number is written into a temporary file first,
+// which then replaces the checkpoint file, so the checkpoint is never left half-written.
+func (cp *FileCheckpoint) Save(blockNum uint32) error {
+	tmpPath := cp.path + ".tmp"
+	content := []byte(strconv.FormatUint(uint64(blockNum), 10) + "\n")
+	if err := ioutil.WriteFile(tmpPath, content, 0644); err != nil {
+		return errors.Wrapf(err, "failed to write checkpoint file: %v", tmpPath)
+	}
+	if err := os.Rename(tmpPath, cp.path); err != nil {
+		return errors.Wrapf(err, "failed to replace checkpoint file: %v", cp.path)
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package stream
+
+import "errors"
+
+var (
+	ErrForkTooDeep = errors.New("fork is deeper than the block history kept")
+)
@@ -0,0 +1,57 @@
+package stream
+
+import (
+	// Stdlib
+	"fmt"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+)
+
+// RetryEvent is emitted when an RPC call fails and is going to be retried.
+type RetryEvent struct {
+	Method  string
+	Err     error
+	Attempt int
+	Delay   time.Duration
+}
+
+func (e *RetryEvent) String() string {
+	return fmt.Sprintf("RETRY [method=%v, attempt=%v, delay=%v, err=%v]",
+		e.Method, e.Attempt, e.Delay, e.Err)
+}
+
+// Applied is emitted by HeadFollower when a block becomes part of the chain being followed.
+type Applied struct {
+	Block *database.Block
+}
+
+func (e *Applied) String() string {
+	return fmt.Sprintf("APPLIED [block=%v, id=%v]", e.Block.Number, e.Block.BlockID)
+}
+
+// Reverted is emitted by HeadFollower when a block previously applied
+// is no longer part of the chain being followed. Blocks are always reverted
+// from the highest one, before any block from the new fork is applied.
+type Reverted struct {
+	BlockNum uint32
+	Block    *database.Block
+}
+
+func (e *Reverted) String() string {
+	return fmt.Sprintf("REVERTED [block=%v, id=%v]", e.BlockNum, e.Block.BlockID)
+}
+
+// ProgressEvent is emitted by Fetcher periodically and once it is done.
+type ProgressEvent struct {
+	Processed    uint32
+	Total        uint32
+	BlocksPerSec float64
+	ETA          time.Duration
+}
+
+func (e *ProgressEvent) String() string {
+	return fmt.Sprintf("PROGRESS [processed=%v/%v, rate=%.1f blocks/s, eta=%v]",
+		e.Processed, e.Total, e.BlocksPerSec, e.ETA)
+}
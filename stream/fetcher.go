@@ -0,0 +1,246 @@
+package stream
+
+import (
+	// Stdlib
+	"context"
+	"sync"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultWorkers          = 8
+	DefaultProgressInterval = 10 * time.Second
+)
+
+// FetcherOption represents an option that can be passed into NewFetcher.
+//
+// Every Option is a FetcherOption as well, it configures the underlying Streamer.
+// Options specific to Fetcher, e.g. SetWorkers, are not an Option,
+// so they cannot be passed into NewStreamer by mistake.
+type FetcherOption interface {
+	applyFetcher(f *Fetcher)
+}
+
+func (opt Option) applyFetcher(f *Fetcher) {
+	opt(f.Streamer)
+}
+
+type fetcherOption func(*Fetcher)
+
+func (opt fetcherOption) applyFetcher(f *Fetcher) {
+	opt(f)
+}
+
+// SetWorkers sets the number of blocks Fetcher is fetching concurrently.
+//
+// The default value is DefaultWorkers.
+func SetWorkers(workers int) FetcherOption {
+	return fetcherOption(func(f *Fetcher) {
+		f.workers = workers
+	})
+}
+
+// SetProgressInterval sets how often Fetcher emits ProgressEvent
+// into the monitoring channel. See SetMonitor.
+//
+// The default value is DefaultProgressInterval.
+func SetProgressInterval(interval time.Duration) FetcherOption {
+	return fetcherOption(func(f *Fetcher) {
+		f.progressInterval = interval
+	})
+}
+
+// Fetcher fetches a range of blocks using multiple concurrent workers,
+// which makes it suitable for backfilling. The blocks are still handled
+// one by one in the block number order, so the same BlockHandler can be used
+// for both the backfill and the live stream, e.g. Operations(handler).
+type Fetcher struct {
+	*Streamer
+
+	workers          int
+	progressInterval time.Duration
+}
+
+// NewFetcher creates a new fetcher using the given API.
+// It accepts the same options as NewStreamer, the mode is ignored.
+func NewFetcher(api BlockAPI, options ...FetcherOption) *Fetcher {
+	f := &Fetcher{Streamer: NewStreamer(api)}
+	for _, opt := range options {
+		opt.applyFetcher(f)
+	}
+	if f.workers < 1 {
+		f.workers = DefaultWorkers
+	}
+	if f.progressInterval == 0 {
+		f.progressInterval = DefaultProgressInterval
+	}
+	return f
+}
+
+type fetchResult struct {
+	block *database.Block
+	err   error
+}
+
+// Run fetches blocks from through to, inclusive, and passes them to the handler in order.
+//
+// When a checkpoint is set and there is a checkpoint stored already,
+// the fetcher resumes with the block following the checkpoint.
+// Blocks that cannot be fetched are retried the same way Streamer does it.
+func (f *Fetcher) Run(ctx context.Context, from, to uint32, handler BlockHandler) error {
+	if f.checkpoint != nil {
+		blockNum, ok, err := f.checkpoint.Load()
+		if err != nil {
+			return err
+		}
+		if ok {
+			from = blockNum + 1
+		}
+	}
+	if from > to {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		jobs    = make(chan uint32)
+		results = make(chan *fetchResult)
+		// Tokens limit how far the workers can get ahead of the handler.
+		tokens = make(chan struct{}, 4*f.workers)
+		wg     sync.WaitGroup
+	)
+
+	// Dispatch block numbers.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for blockNum := from; blockNum <= to && blockNum >= from; blockNum++ {
+			select {
+			case tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- blockNum:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Start the workers.
+	for i := 0; i < f.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for blockNum := range jobs {
+				block, err := f.getBlock(ctx, blockNum)
+				if err == nil {
+					block.Number = blockNum
+				} else {
+					err = errors.Wrapf(err, "stream: failed to fetch block %v", blockNum)
+				}
+				select {
+				case results <- &fetchResult{block, err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// Make sure the goroutines are gone before returning.
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// Handle the blocks in order.
+	var (
+		pending  = make(map[uint32]*database.Block)
+		next     = from
+		progress = newProgress(to - from + 1)
+	)
+	for {
+		select {
+		case res := <-results:
+			if res.err != nil {
+				return res.err
+			}
+			pending[res.block.Number] = res.block
+
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		for {
+			block, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			<-tokens
+
+			if err := handler.HandleBlock(block); err != nil {
+				return err
+			}
+			if f.checkpoint != nil {
+				if err := f.checkpoint.Save(next); err != nil {
+					return err
+				}
+			}
+
+			progress.processed++
+			if next == to {
+				f.emitEvent(progress.event())
+				return nil
+			}
+			if progress.due(f.progressInterval) {
+				f.emitEvent(progress.event())
+			}
+			next++
+		}
+	}
+}
+
+type progress struct {
+	total      uint32
+	processed  uint32
+	start      time.Time
+	lastReport time.Time
+}
+
+func newProgress(total uint32) *progress {
+	now := time.Now()
+	return &progress{total: total, start: now, lastReport: now}
+}
+
+func (p *progress) due(interval time.Duration) bool {
+	return time.Since(p.lastReport) >= interval
+}
+
+func (p *progress) event() *ProgressEvent {
+	p.lastReport = time.Now()
+
+	event := &ProgressEvent{
+		Processed: p.processed,
+		Total:     p.total,
+	}
+	if elapsed := p.lastReport.Sub(p.start).Seconds(); elapsed > 0 {
+		event.BlocksPerSec = float64(p.processed) / elapsed
+	}
+	if event.BlocksPerSec > 0 {
+		remaining := float64(p.total - p.processed)
+		event.ETA = time.Duration(remaining / event.BlocksPerSec * float64(time.Second))
+	}
+	return event
+}
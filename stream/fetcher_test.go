@@ -0,0 +1,63 @@
+package stream
+
+import (
+	// Stdlib
+	"context"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+)
+
+func TestFetcher_Run(t *testing.T) {
+	api := &fakeAPI{head: 1000, failures: 5}
+	monitorChan := make(chan interface{}, 100)
+	f := NewFetcher(api,
+		SetWorkers(4),
+		SetRetryDelay(time.Millisecond),
+		SetMonitor(monitorChan))
+
+	var got []uint32
+	err := f.Run(context.Background(), 10, 200, BlockHandlerFunc(func(block *database.Block) error {
+		got = append(got, block.Number)
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 191 {
+		t.Fatalf("expected 191 blocks, got %v", len(got))
+	}
+	for i, blockNum := range got {
+		if blockNum != uint32(10+i) {
+			t.Fatalf("expected block %v at position %v, got %v", 10+i, i, blockNum)
+		}
+	}
+
+	close(monitorChan)
+	var last *ProgressEvent
+	for event := range monitorChan {
+		if progress, ok := event.(*ProgressEvent); ok {
+			last = progress
+		}
+	}
+	if last == nil || last.Processed != 191 || last.Total != 191 {
+		t.Errorf("expected the final progress event, got %v", last)
+	}
+}
+
+func TestNewFetcher_Options(t *testing.T) {
+	f := NewFetcher(&fakeAPI{}, SetRetryDelay(time.Millisecond))
+	if f.retryDelay != time.Millisecond || f.workers != DefaultWorkers ||
+		f.progressInterval != DefaultProgressInterval {
+		t.Errorf("unexpected options: retry delay %v, workers %v, progress interval %v",
+			f.retryDelay, f.workers, f.progressInterval)
+	}
+
+	f = NewFetcher(&fakeAPI{}, SetWorkers(2), SetProgressInterval(time.Second))
+	if f.workers != 2 || f.progressInterval != time.Second {
+		t.Errorf("unexpected options: workers %v, progress interval %v", f.workers, f.progressInterval)
+	}
+}
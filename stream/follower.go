@@ -0,0 +1,224 @@
+package stream
+
+import (
+	// Stdlib
+	"context"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// DefaultHistorySize is the default number of recent blocks kept by HeadFollower.
+const DefaultHistorySize = 100
+
+// FollowerOption represents an option that can be passed into NewHeadFollower.
+//
+// Every Option is a FollowerOption as well, it configures the underlying Streamer.
+// Options specific to HeadFollower, e.g. SetHistorySize, are not an Option,
+// so they cannot be passed into NewStreamer by mistake.
+type FollowerOption interface {
+	applyFollower(f *HeadFollower)
+}
+
+func (opt Option) applyFollower(f *HeadFollower) {
+	opt(f.Streamer)
+}
+
+type followerOption func(*HeadFollower)
+
+func (opt followerOption) applyFollower(f *HeadFollower) {
+	opt(f)
+}
+
+// SetHistorySize sets how many recent blocks HeadFollower keeps to be able
+// to walk back a fork. The value must be greater than the distance between
+// the head block and the last irreversible block.
+//
+// The default value is DefaultHistorySize.
+func SetHistorySize(size int) FollowerOption {
+	return followerOption(func(f *HeadFollower) {
+		f.historySize = size
+	})
+}
+
+// EventHandler processes events emitted by HeadFollower, i.e. *Applied and *Reverted.
+//
+// Returning an error stops the follower, the error is then returned from Run.
+type EventHandler interface {
+	HandleEvent(event interface{}) error
+}
+
+// EventHandlerFunc is an adapter allowing to use an ordinary function as an EventHandler.
+type EventHandlerFunc func(event interface{}) error
+
+// HandleEvent implements EventHandler.
+func (f EventHandlerFunc) HandleEvent(event interface{}) error {
+	return f(event)
+}
+
+// HeadFollower follows the head block and detects blocks being replaced by a fork.
+//
+// Every new block is checked to link to the previously applied block
+// using Block.Previous. When it does not, the previously applied blocks are
+// reverted one by one until the chain links again, then the blocks
+// from the new fork are applied.
+type HeadFollower struct {
+	*Streamer
+
+	historySize int
+	history     []*historyEntry
+}
+
+type historyEntry struct {
+	block   *database.Block
+	id      string
+	applied bool
+}
+
+func newHistoryEntry(block *database.Block, applied bool) (*historyEntry, error) {
+	// Older nodes do not send the block ID, compute it in that case.
+	id := block.BlockID
+	if id == "" {
+		var err error
+		if id, err = block.ID(); err != nil {
+			return nil, errors.Wrapf(err, "stream: failed to compute ID of block %v", block.Number)
+		}
+	}
+	return &historyEntry{block, id, applied}, nil
+}
+
+// NewHeadFollower creates a new head follower using the given API.
+// It accepts the same options as NewStreamer, the mode is always ModeHead.
+func NewHeadFollower(api BlockAPI, options ...FollowerOption) *HeadFollower {
+	f := &HeadFollower{Streamer: NewStreamer(api)}
+	for _, opt := range options {
+		opt.applyFollower(f)
+	}
+	f.mode = ModeHead
+	if f.historySize < 1 {
+		f.historySize = DefaultHistorySize
+	}
+	return f
+}
+
+// Run keeps emitting events until the context is cancelled or the handler fails.
+//
+// ErrForkTooDeep is returned when a fork reaches below the oldest block kept.
+func (f *HeadFollower) Run(ctx context.Context, handler EventHandler) error {
+	interval, err := f.getPollInterval(ctx)
+	if err != nil {
+		return err
+	}
+
+	next, err := f.getStartBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The block preceding the start block is needed to check the start block.
+	// It is not emitted, it was either processed already or it is not wanted.
+	f.history = nil
+	if next > 1 {
+		prev, err := f.getBlock(ctx, next-1)
+		if err != nil {
+			return err
+		}
+		entry, err := newHistoryEntry(prev, false)
+		if err != nil {
+			return err
+		}
+		f.history = append(f.history, entry)
+	}
+
+	for {
+		props, err := f.getProps(ctx)
+		if err != nil {
+			return err
+		}
+
+		for next <= uint32(props.HeadBlockNumber) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			block, err := f.getBlock(ctx, next)
+			if err != nil {
+				return err
+			}
+
+			// Walk back one block in case the new block does not link to our tip.
+			if tip := f.tip(); tip != nil && block.Previous != tip.id {
+				// The block preceding the start block was replaced, just fetch it again.
+				if !tip.applied {
+					prev, err := f.getBlock(ctx, tip.block.Number)
+					if err != nil {
+						return err
+					}
+					entry, err := newHistoryEntry(prev, false)
+					if err != nil {
+						return err
+					}
+					f.history = []*historyEntry{entry}
+					continue
+				}
+
+				if len(f.history) == 1 {
+					return ErrForkTooDeep
+				}
+
+				f.history = f.history[:len(f.history)-1]
+				if err := handler.HandleEvent(&Reverted{tip.block.Number, tip.block}); err != nil {
+					return err
+				}
+				if err := f.saveCheckpoint(tip.block.Number - 1); err != nil {
+					return err
+				}
+
+				next = tip.block.Number
+				continue
+			}
+
+			entry, err := newHistoryEntry(block, true)
+			if err != nil {
+				return err
+			}
+			if err := handler.HandleEvent(&Applied{block}); err != nil {
+				return err
+			}
+			f.push(entry)
+
+			if err := f.saveCheckpoint(next); err != nil {
+				return err
+			}
+			next++
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+func (f *HeadFollower) tip() *historyEntry {
+	if len(f.history) == 0 {
+		return nil
+	}
+	return f.history[len(f.history)-1]
+}
+
+func (f *HeadFollower) push(entry *historyEntry) {
+	f.history = append(f.history, entry)
+	if len(f.history) > f.historySize {
+		f.history = f.history[len(f.history)-f.historySize:]
+	}
+}
+
+func (f *HeadFollower) saveCheckpoint(blockNum uint32) error {
+	if f.checkpoint == nil {
+		return nil
+	}
+	return f.checkpoint.Save(blockNum)
+}
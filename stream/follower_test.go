@@ -0,0 +1,116 @@
+package stream
+
+import (
+	// Stdlib
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/types"
+)
+
+// forkingAPI serves a chain of 10 blocks. Once block 8 is fetched,
+// blocks 7 and up are replaced by a fork producing blocks up to 12.
+type forkingAPI struct {
+	mu     sync.Mutex
+	head   uint32
+	forked bool
+}
+
+func (api *forkingAPI) GetConfig() (*database.Config, error) {
+	return &database.Config{ScorumBlockInterval: 3}, nil
+}
+
+func (api *forkingAPI) GetDynamicGlobalProperties() (*database.DynamicGlobalProperties, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	return &database.DynamicGlobalProperties{HeadBlockNumber: types.UInt32(api.head)}, nil
+}
+
+func (api *forkingAPI) GetBlock(blockNum uint32) (*database.Block, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	id := func(num uint32) string {
+		if api.forked && num >= 7 {
+			return fmt.Sprintf("b%v", num)
+		}
+		return fmt.Sprintf("a%v", num)
+	}
+
+	block := &database.Block{
+		Number:   blockNum,
+		BlockID:  id(blockNum),
+		Previous: id(blockNum - 1),
+	}
+	if blockNum <= api.head {
+		now := time.Now()
+		block.Timestamp = &types.Time{Time: &now}
+	}
+
+	if blockNum == 8 && !api.forked {
+		api.forked = true
+		api.head = 12
+	}
+	return block, nil
+}
+
+func (api *forkingAPI) GetBlockHeader(blockNum uint32) (*database.BlockHeader, error) {
+	block, err := api.GetBlock(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+func (api *forkingAPI) GetOpsInBlock(blockNum uint32, onlyVirtual bool) ([]*database.AppliedOperation, error) {
+	return nil, nil
+}
+
+func TestHeadFollower_Run(t *testing.T) {
+	api := &forkingAPI{head: 10}
+	f := NewHeadFollower(api, SetStartBlock(5), SetPollInterval(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []string
+	err := f.Run(ctx, EventHandlerFunc(func(event interface{}) error {
+		switch event := event.(type) {
+		case *Applied:
+			got = append(got, "+"+event.Block.BlockID)
+			if event.Block.Number == 12 {
+				cancel()
+			}
+		case *Reverted:
+			got = append(got, "-"+event.Block.BlockID)
+		}
+		return nil
+	}))
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	expected := "[+a5 +a6 +a7 +a8 -a8 -a7 +b7 +b8 +b9 +b10 +b11 +b12]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestNewHeadFollower_Options(t *testing.T) {
+	f := NewHeadFollower(&forkingAPI{}, SetMode(ModeIrreversible), SetPollInterval(time.Second))
+	if f.mode != ModeHead || f.pollInterval != time.Second || f.historySize != DefaultHistorySize {
+		t.Errorf("unexpected options: mode %v, poll interval %v, history size %v",
+			f.mode, f.pollInterval, f.historySize)
+	}
+
+	f = NewHeadFollower(&forkingAPI{}, SetHistorySize(5))
+	if f.historySize != 5 {
+		t.Errorf("expected history size 5, got %v", f.historySize)
+	}
+}
@@ -0,0 +1,120 @@
+package stream
+
+import (
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/types"
+)
+
+// BlockHandler processes blocks emitted by a streamer.
+//
+// Returning an error stops the streamer, the error is then returned from Run.
+type BlockHandler interface {
+	HandleBlock(block *database.Block) error
+}
+
+// BlockHandlerFunc is an adapter allowing to use an ordinary function as a BlockHandler.
+type BlockHandlerFunc func(block *database.Block) error
+
+// HandleBlock implements BlockHandler.
+func (f BlockHandlerFunc) HandleBlock(block *database.Block) error {
+	return f(block)
+}
+
+// HeaderHandler processes block headers emitted by a streamer.
+//
+// Returning an error stops the streamer, the error is then returned from RunHeaders.
+type HeaderHandler interface {
+	HandleHeader(header *database.BlockHeader) error
+}
+
+// HeaderHandlerFunc is an adapter allowing to use an ordinary function as a HeaderHandler.
+type HeaderHandlerFunc func(header *database.BlockHeader) error
+
+// HandleHeader implements HeaderHandler.
+func (f HeaderHandlerFunc) HandleHeader(header *database.BlockHeader) error {
+	return f(header)
+}
+
+// Operation is an operation together with its position in the blockchain.
+//
+// Virtual operations are positioned after the operation that triggered them.
+// Virtual operations not triggered by any transaction, e.g. author_reward,
+// come after all the transactions and have TrxIndex and OpIndex set to -1.
+type Operation struct {
+	BlockNum    uint32
+	Timestamp   *types.Time
+	TrxIndex    int
+	OpIndex     int
+	Transaction *types.Transaction
+	Operation   types.Operation
+}
+
+// IsVirtual returns true when the operation was generated by the blockchain itself.
+func (op *Operation) IsVirtual() bool {
+	return op.Operation.Type().IsVirtual()
+}
+
+// OperationHandler processes operations emitted by a streamer.
+//
+// Returning an error stops the streamer, the error is then returned from Run.
+type OperationHandler interface {
+	HandleOperation(op *Operation) error
+}
+
+// OperationHandlerFunc is an adapter allowing to use an ordinary function as an OperationHandler.
+type OperationHandlerFunc func(op *Operation) error
+
+// HandleOperation implements OperationHandler.
+func (f OperationHandlerFunc) HandleOperation(op *Operation) error {
+	return f(op)
+}
+
+// Operations turns an OperationHandler into a BlockHandler
+// calling the operation handler for every operation in the block, in order.
+//
+// Block.VirtualOperations, when set using SetVirtualOperations,
+// are merged into the transaction operations in the order they were applied.
+func Operations(handler OperationHandler) BlockHandler {
+	return BlockHandlerFunc(func(block *database.Block) error {
+		virtualOps := block.VirtualOperations
+
+		emit := func(trxIndex, opIndex int, tx *types.Transaction, op types.Operation) error {
+			return handler.HandleOperation(&Operation{
+				BlockNum:    block.Number,
+				Timestamp:   block.Timestamp,
+				TrxIndex:    trxIndex,
+				OpIndex:     opIndex,
+				Transaction: tx,
+				Operation:   op,
+			})
+		}
+
+		for trxIndex, tx := range block.Transactions {
+			for opIndex, op := range tx.Operations {
+				if err := emit(trxIndex, opIndex, tx, op); err != nil {
+					return err
+				}
+
+				// Emit the virtual operations triggered by this operation.
+				for len(virtualOps) != 0 &&
+					virtualOps[0].TrxInBlock == uint32(trxIndex) &&
+					virtualOps[0].OpInTrx == uint32(opIndex) {
+
+					if err := emit(trxIndex, opIndex, tx, virtualOps[0].Operation); err != nil {
+						return err
+					}
+					virtualOps = virtualOps[1:]
+				}
+			}
+		}
+
+		// The rest was generated while processing the block itself.
+		for _, op := range virtualOps {
+			if err := emit(-1, -1, nil, op.Operation); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
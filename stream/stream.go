@@ -0,0 +1,385 @@
+// Package stream turns the polling of database_api into a stream of blocks or operations.
+package stream
+
+import (
+	// Stdlib
+	"context"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRetryDelay    = 1 * time.Second
+	DefaultMaxRetryDelay = 1 * time.Minute
+)
+
+// Mode decides how far the streamer is following the blockchain.
+type Mode int
+
+const (
+	// ModeIrreversible only emits blocks up to the last irreversible block.
+	// The blocks emitted can never be reverted.
+	ModeIrreversible Mode = iota
+
+	// ModeHead emits blocks up to the head block.
+	// The blocks are emitted sooner, but they can be still reverted by a fork.
+	ModeHead
+)
+
+// BlockAPI is the subset of database_api the streamer is using.
+// It is implemented by *database.API.
+type BlockAPI interface {
+	GetConfig() (*database.Config, error)
+	GetDynamicGlobalProperties() (*database.DynamicGlobalProperties, error)
+	GetBlock(blockNum uint32) (*database.Block, error)
+	GetBlockHeader(blockNum uint32) (*database.BlockHeader, error)
+	GetOpsInBlock(blockNum uint32, onlyVirtual bool) ([]*database.AppliedOperation, error)
+}
+
+// Streamer keeps polling the node and emits new blocks as they are produced.
+type Streamer struct {
+	api BlockAPI
+
+	// Options.
+	mode          Mode
+	startBlock    uint32
+	checkpoint    Checkpoint
+	pollInterval  time.Duration
+	retryDelay    time.Duration
+	maxRetryDelay time.Duration
+	maxRetries    int
+	virtualOps    bool
+
+	monitorChan chan<- interface{}
+}
+
+// Option represents an option that can be passed into the streamer constructor.
+type Option func(*Streamer)
+
+// SetMode sets the streaming mode. The default mode is ModeIrreversible.
+func SetMode(mode Mode) Option {
+	return func(s *Streamer) {
+		s.mode = mode
+	}
+}
+
+// SetStartBlock sets the first block to be emitted.
+//
+// By default the streamer starts with the current last irreversible block
+// or the current head block, depending on the mode.
+func SetStartBlock(blockNum uint32) Option {
+	return func(s *Streamer) {
+		s.startBlock = blockNum
+	}
+}
+
+// SetCheckpoint sets the checkpoint to resume from.
+//
+// The checkpoint is updated every time a block is processed successfully.
+// When a checkpoint is stored already, it takes precedence over SetStartBlock.
+func SetCheckpoint(checkpoint Checkpoint) Option {
+	return func(s *Streamer) {
+		s.checkpoint = checkpoint
+	}
+}
+
+// SetPollInterval sets how long to wait before asking the node for new blocks again.
+//
+// The default value is SCORUM_BLOCK_INTERVAL as returned by get_config.
+func SetPollInterval(interval time.Duration) Option {
+	return func(s *Streamer) {
+		s.pollInterval = interval
+	}
+}
+
+// SetVirtualOperations makes the streamer fetch virtual operations
+// using get_ops_in_block and store them in Block.VirtualOperations.
+// Operations then emits them together with the transaction operations.
+//
+// This costs an extra RPC call per block, so it is disabled by default.
+func SetVirtualOperations(enabled bool) Option {
+	return func(s *Streamer) {
+		s.virtualOps = enabled
+	}
+}
+
+// SetRetryDelay sets the delay before the first retry of a failed RPC call.
+// The delay is doubled on every subsequent attempt.
+//
+// The default value is 1 second.
+func SetRetryDelay(delay time.Duration) Option {
+	return func(s *Streamer) {
+		s.retryDelay = delay
+	}
+}
+
+// SetMaxRetryDelay sets the maximum delay between retries of a failed RPC call.
+//
+// The default value is 1 minute.
+func SetMaxRetryDelay(delay time.Duration) Option {
+	return func(s *Streamer) {
+		s.maxRetryDelay = delay
+	}
+}
+
+// SetMaxRetries sets how many times a failed RPC call is retried
+// before the error is returned from Run. Zero means retrying forever.
+//
+// The default value is 0.
+func SetMaxRetries(retries int) Option {
+	return func(s *Streamer) {
+		s.maxRetries = retries
+	}
+}
+
+// SetMonitor can be used to set the monitoring channel receiving RetryEvent
+// every time an RPC call fails and is going to be retried.
+//
+// All channel send operations are happening synchronously, so not receiving messages
+// from the channel will lead to the whole thing getting stuck completely.
+func SetMonitor(monitorChan chan<- interface{}) Option {
+	return func(s *Streamer) {
+		s.monitorChan = monitorChan
+	}
+}
+
+// NewStreamer creates a new streamer using the given API.
+func NewStreamer(api BlockAPI, options ...Option) *Streamer {
+	s := &Streamer{
+		api:           api,
+		retryDelay:    DefaultRetryDelay,
+		maxRetryDelay: DefaultMaxRetryDelay,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+	return s
+}
+
+// Run keeps emitting blocks until the context is cancelled or the handler fails.
+//
+// Blocks are emitted in order, one by one. RPC errors are retried using
+// exponential backoff, handler errors are returned immediately.
+// The context error is returned when the context is cancelled.
+func (s *Streamer) Run(ctx context.Context, handler BlockHandler) error {
+	return s.run(ctx, func(blockNum uint32) error {
+		block, err := s.getBlock(ctx, blockNum)
+		if err != nil {
+			return err
+		}
+		return handler.HandleBlock(block)
+	})
+}
+
+// RunHeaders works the same way as Run, but only block headers are fetched.
+//
+// Use it when the transactions are not needed, e.g. to track block production.
+// It is much cheaper than Run, since the headers are small and contain no operations.
+// For the same reason RunHeaders fails when SetVirtualOperations is enabled.
+func (s *Streamer) RunHeaders(ctx context.Context, handler HeaderHandler) error {
+	if s.virtualOps {
+		return errors.New("virtual operations cannot be streamed with block headers")
+	}
+
+	return s.run(ctx, func(blockNum uint32) error {
+		header, err := s.getBlockHeader(ctx, blockNum)
+		if err != nil {
+			return err
+		}
+		return handler.HandleHeader(header)
+	})
+}
+
+// run calls process for every block number to be emitted.
+func (s *Streamer) run(ctx context.Context, process func(blockNum uint32) error) error {
+	interval, err := s.getPollInterval(ctx)
+	if err != nil {
+		return err
+	}
+
+	next, err := s.getStartBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	for {
+		props, err := s.getProps(ctx)
+		if err != nil {
+			return err
+		}
+
+		for last := s.lastBlockNum(props); next <= last; next++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if err := process(next); err != nil {
+				return err
+			}
+
+			if s.checkpoint != nil {
+				if err := s.checkpoint.Save(next); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Streamer) getPollInterval(ctx context.Context) (time.Duration, error) {
+	if s.pollInterval != 0 {
+		return s.pollInterval, nil
+	}
+
+	var config *database.Config
+	err := s.retry(ctx, "get_config", func() (err error) {
+		config, err = s.api.GetConfig()
+		return
+	})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(config.ScorumBlockInterval) * time.Second, nil
+}
+
+func (s *Streamer) getStartBlock(ctx context.Context) (uint32, error) {
+	if s.checkpoint != nil {
+		blockNum, ok, err := s.checkpoint.Load()
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return blockNum + 1, nil
+		}
+	}
+
+	if s.startBlock != 0 {
+		return s.startBlock, nil
+	}
+
+	props, err := s.getProps(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return s.lastBlockNum(props), nil
+}
+
+func (s *Streamer) lastBlockNum(props *database.DynamicGlobalProperties) uint32 {
+	if s.mode == ModeHead {
+		return uint32(props.HeadBlockNumber)
+	}
+	return props.LastIrreversibleBlockNum
+}
+
+func (s *Streamer) getProps(ctx context.Context) (*database.DynamicGlobalProperties, error) {
+	var props *database.DynamicGlobalProperties
+	err := s.retry(ctx, "get_dynamic_global_properties", func() (err error) {
+		props, err = s.api.GetDynamicGlobalProperties()
+		return
+	})
+	return props, err
+}
+
+func (s *Streamer) getBlock(ctx context.Context, blockNum uint32) (*database.Block, error) {
+	var block *database.Block
+	err := s.retry(ctx, "get_block", func() (err error) {
+		block, err = s.api.GetBlock(blockNum)
+		if err == nil && block.Timestamp == nil {
+			// The node returned null, e.g. a node behind a load balancer
+			// that is not synchronized yet. Treat it as a temporary error.
+			err = errors.Errorf("block %v not found", blockNum)
+		}
+		return
+	})
+	if err != nil || !s.virtualOps {
+		return block, err
+	}
+
+	err = s.retry(ctx, "get_ops_in_block", func() (err error) {
+		block.VirtualOperations, err = s.api.GetOpsInBlock(blockNum, true)
+		return
+	})
+	return block, err
+}
+
+func (s *Streamer) getBlockHeader(ctx context.Context, blockNum uint32) (*database.BlockHeader, error) {
+	var header *database.BlockHeader
+	err := s.retry(ctx, "get_block_header", func() (err error) {
+		header, err = s.api.GetBlockHeader(blockNum)
+		if err == nil && header.Timestamp == nil {
+			// Treated as a temporary error the same way as in getBlock.
+			err = errors.Errorf("block %v not found", blockNum)
+		}
+		return
+	})
+	return header, err
+}
+
+// Retry calls fn the same way the streamer calls the RPC methods, i.e. using
+// exponential backoff and emitting RetryEvent on every failure.
+// The method name is only used in the events and in the error returned.
+//
+// It is useful to make the calls the block processing depends on
+// with the same retry policy as the streamer itself.
+func (s *Streamer) Retry(ctx context.Context, method string, fn func() error) error {
+	return s.retry(ctx, method, fn)
+}
+
+// retry keeps calling fn using exponential backoff until it succeeds,
+// the maximum number of retries is reached or the context is cancelled.
+func (s *Streamer) retry(ctx context.Context, method string, fn func() error) error {
+	delay := s.retryDelay
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if s.maxRetries != 0 && attempt > s.maxRetries {
+			return errors.Wrapf(err, "stream: %v failed", method)
+		}
+
+		s.emitEvent(&RetryEvent{
+			Method:  method,
+			Err:     err,
+			Attempt: attempt,
+			Delay:   delay,
+		})
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+
+		delay = 2 * delay
+		if delay > s.maxRetryDelay {
+			delay = s.maxRetryDelay
+		}
+	}
+}
+
+func (s *Streamer) emitEvent(event interface{}) {
+	if ch := s.monitorChan; ch != nil {
+		ch <- event
+	}
+}
+
+// sleep waits for the given period or until the context is cancelled.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,218 @@
+package stream
+
+import (
+	// Stdlib
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/types"
+)
+
+// fakeAPI serves blocks 1..head, head growing by one on every
+// get_dynamic_global_properties call. The irreversible block lags 3 blocks behind.
+type fakeAPI struct {
+	mu       sync.Mutex
+	head     uint32
+	failures int
+}
+
+func (api *fakeAPI) GetConfig() (*database.Config, error) {
+	return &database.Config{ScorumBlockInterval: 3}, nil
+}
+
+func (api *fakeAPI) GetDynamicGlobalProperties() (*database.DynamicGlobalProperties, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.head++
+	return &database.DynamicGlobalProperties{
+		HeadBlockNumber:          types.UInt32(api.head),
+		LastIrreversibleBlockNum: api.head - 3,
+	}, nil
+}
+
+func (api *fakeAPI) GetBlock(blockNum uint32) (*database.Block, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if api.failures > 0 {
+		api.failures--
+		return nil, errors.New("connection reset")
+	}
+	if blockNum > api.head {
+		return &database.Block{Number: blockNum}, nil
+	}
+	now := time.Now()
+	return &database.Block{Number: blockNum, Timestamp: &types.Time{Time: &now}}, nil
+}
+
+func (api *fakeAPI) GetBlockHeader(blockNum uint32) (*database.BlockHeader, error) {
+	block, err := api.GetBlock(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+func (api *fakeAPI) GetOpsInBlock(blockNum uint32, onlyVirtual bool) ([]*database.AppliedOperation, error) {
+	return nil, nil
+}
+
+func collect(t *testing.T, s *Streamer, count int) []uint32 {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []uint32
+	err := s.Run(ctx, BlockHandlerFunc(func(block *database.Block) error {
+		got = append(got, block.Number)
+		if len(got) == count {
+			cancel()
+		}
+		return nil
+	}))
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	return got
+}
+
+func TestStreamer_Run(t *testing.T) {
+	api := &fakeAPI{head: 10, failures: 2}
+	s := NewStreamer(api,
+		SetStartBlock(5),
+		SetPollInterval(time.Millisecond),
+		SetRetryDelay(time.Millisecond))
+
+	got := collect(t, s, 10)
+	for i, blockNum := range got {
+		if blockNum != uint32(5+i) {
+			t.Fatalf("expected blocks 5..14 in order, got %v", got)
+		}
+	}
+}
+
+func TestStreamer_Checkpoint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	api := &fakeAPI{head: 100}
+	checkpoint := NewFileCheckpoint(filepath.Join(dir, "checkpoint"))
+	options := []Option{
+		SetMode(ModeHead),
+		SetStartBlock(1),
+		SetCheckpoint(checkpoint),
+		SetPollInterval(time.Millisecond),
+	}
+
+	collect(t, NewStreamer(api, options...), 3)
+
+	got := collect(t, NewStreamer(api, options...), 1)
+	if got[0] != 4 {
+		t.Errorf("expected to resume with block 4, got %v", got[0])
+	}
+}
+
+func TestStreamer_RunHeaders(t *testing.T) {
+	api := &fakeAPI{head: 10, failures: 1}
+	s := NewStreamer(api,
+		SetMode(ModeHead),
+		SetStartBlock(8),
+		SetPollInterval(time.Millisecond),
+		SetRetryDelay(time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []uint32
+	err := s.RunHeaders(ctx, HeaderHandlerFunc(func(header *database.BlockHeader) error {
+		if header.Timestamp == nil {
+			t.Errorf("block %v: header timestamp not set", header.Number)
+		}
+		got = append(got, header.Number)
+		if len(got) == 5 {
+			cancel()
+		}
+		return nil
+	}))
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	for i, blockNum := range got {
+		if blockNum != uint32(8+i) {
+			t.Fatalf("expected blocks 8..12 in order, got %v", got)
+		}
+	}
+}
+
+func TestStreamer_RunHeaders_VirtualOperations(t *testing.T) {
+	s := NewStreamer(&fakeAPI{head: 10}, SetVirtualOperations(true))
+	err := s.RunHeaders(context.Background(), HeaderHandlerFunc(func(header *database.BlockHeader) error {
+		t.Errorf("unexpected header %v", header.Number)
+		return nil
+	}))
+	if err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestOperations_VirtualOperations(t *testing.T) {
+	var virtualOps []*database.AppliedOperation
+	err := json.Unmarshal([]byte(`[
+		{"trx_id": "0000000000000000000000000000000000000000", "block": 1, "trx_in_block": 4294967295,
+		 "op_in_trx": 0, "virtual_op": 2, "timestamp": "2016-08-08T12:24:17",
+		 "op": ["author_reward", {"author": "xeroc", "permlink": "piston"}]},
+		{"trx_id": "0000000000000000000000000000000000000000", "block": 1, "trx_in_block": 4294967295,
+		 "op_in_trx": 0, "virtual_op": 3, "timestamp": "2016-08-08T12:24:17",
+		 "op": ["curation_reward", {"curator": "xeroc"}]}
+	]`), &virtualOps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Pretend the first one was triggered by the second operation of the first transaction.
+	virtualOps[0].TrxInBlock = 0
+	virtualOps[0].OpInTrx = 1
+
+	block := &database.Block{
+		Number: 1,
+		Transactions: []*types.Transaction{
+			{Operations: types.Operations{&types.VoteOperation{}, &types.CommentOperation{}}},
+			{Operations: types.Operations{&types.VoteOperation{}}},
+		},
+		VirtualOperations: virtualOps,
+	}
+
+	var got []string
+	handler := Operations(OperationHandlerFunc(func(op *Operation) error {
+		got = append(got, fmt.Sprintf("%v/%v/%v/%v", op.TrxIndex, op.OpIndex, op.Operation.Type(), op.IsVirtual()))
+		return nil
+	}))
+	if err := handler.HandleBlock(block); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"0/0/vote/false",
+		"0/1/comment/false",
+		"0/1/author_reward/true",
+		"1/0/vote/false",
+		"-1/-1/curation_reward/true",
+	}
+	if strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
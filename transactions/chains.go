@@ -1,11 +1,21 @@
 package transactions
 
+import (
+	// RPC
+	"github.com/goscorum/scorumgo/types"
+)
+
 type Chain struct {
 	ID string
+
+	// OpCatalog is used to serialize the transactions signed for the chain.
+	// ScorumOpCatalog is used when it is not set.
+	OpCatalog *types.OpCatalog
 }
 
 var SteemChain = &Chain{
-	ID: "0000000000000000000000000000000000000000000000000000000000000000",
+	ID:        "0000000000000000000000000000000000000000000000000000000000000000",
+	OpCatalog: types.SteemOpCatalog,
 }
 
 var TestChain = &Chain{
@@ -30,9 +30,14 @@ func NewSignedTransaction(tx *types.Transaction) *SignedTransaction {
 	return &SignedTransaction{tx}
 }
 
+// Serialize serializes the transaction using types.ScorumOpCatalog.
 func (tx *SignedTransaction) Serialize() ([]byte, error) {
+	return tx.serialize(types.ScorumOpCatalog)
+}
+
+func (tx *SignedTransaction) serialize(catalog *types.OpCatalog) ([]byte, error) {
 	var b bytes.Buffer
-	encoder := transaction.NewEncoder(&b)
+	encoder := transaction.NewEncoder(&b, transaction.SetCatalog(catalog))
 
 	if err := encoder.Encode(tx.Transaction); err != nil {
 		return nil, err
@@ -54,7 +59,7 @@ func (tx *SignedTransaction) Digest(chain *Chain) ([]byte, error) {
 	}
 
 	// Write the serialized transaction.
-	rawTx, err := tx.Serialize()
+	rawTx, err := tx.serialize(chain.OpCatalog)
 	if err != nil {
 		return nil, err
 	}
@@ -71,10 +76,16 @@ func (tx *SignedTransaction) Digest(chain *Chain) ([]byte, error) {
 // get rid of cgo and lsecp256k1
 func (tx *SignedTransaction) Sign(privKeys [][]byte, chain *Chain) error {
 	var buf bytes.Buffer
-	chainid, _ := hex.DecodeString(chain.ID)
+	chainid, err := hex.DecodeString(chain.ID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decode chain ID: %v", chain.ID)
+	}
 	//fmt.Println(tx.Operations[0])
 	//fmt.Println(" ")
-	tx_raw, _ := tx.Serialize()
+	tx_raw, err := tx.serialize(chain.OpCatalog)
+	if err != nil {
+		return err
+	}
 	//fmt.Println(tx_raw)
 	//fmt.Println(" ")
 	buf.Write(chainid)
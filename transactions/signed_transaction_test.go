@@ -96,3 +96,34 @@ func TestTransaction_SignAndVerify(t *testing.T) {
 		t.Error("verification failed")
 	}
 }
+
+func TestTransaction_Sign_FollowPlugin(t *testing.T) {
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := &types.Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &types.Time{&expiration},
+	}
+
+	op, err := types.NewFollowOperation(types.SteemOpCatalog, "alice", "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.PushOperation(op)
+
+	// The default catalog has no custom_json, so the transaction cannot be signed.
+	stx := NewSignedTransaction(tx)
+	if err := stx.Sign(privateKeys, TestChain); err == nil {
+		t.Error("expected an error signing custom_json with the default catalog")
+	}
+	if len(tx.Signatures) != 0 {
+		t.Errorf("expected no signatures, got %v", tx.Signatures)
+	}
+
+	if err := stx.Sign(privateKeys, SteemChain); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Signatures) != 1 {
+		t.Errorf("expected a single signature, got %v", tx.Signatures)
+	}
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	// Stdlib
+	"strings"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinAccountNameLength is the minimum account name length,
+	// see SCORUM_MIN_ACCOUNT_NAME_LENGTH as returned by get_config.
+	MinAccountNameLength = 3
+
+	// MaxAccountNameLength is the maximum account name length,
+	// see SCORUM_MAX_ACCOUNT_NAME_LENGTH as returned by get_config.
+	MaxAccountNameLength = 16
+)
+
+// ValidateAccountName checks the account name the same way the node does.
+//
+// The name is a list of labels separated by dots. Every label is at least
+// MinAccountNameLength characters long, it starts with a lowercase letter,
+// it ends with a lowercase letter or a digit and it contains
+// only lowercase letters, digits and dashes.
+func ValidateAccountName(name string) error {
+	if len(name) < MinAccountNameLength || len(name) > MaxAccountNameLength {
+		return errors.Errorf("invalid account name %q: length must be between %v and %v",
+			name, MinAccountNameLength, MaxAccountNameLength)
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if len(label) < MinAccountNameLength {
+			return errors.Errorf("invalid account name %q: each label must be at least %v characters long",
+				name, MinAccountNameLength)
+		}
+		if !isLower(label[0]) {
+			return errors.Errorf("invalid account name %q: each label must start with a letter", name)
+		}
+		if last := label[len(label)-1]; !isLower(last) && !isDigit(last) {
+			return errors.Errorf("invalid account name %q: each label must end with a letter or a digit", name)
+		}
+		for i := 1; i < len(label)-1; i++ {
+			if c := label[i]; !isLower(c) && !isDigit(c) && c != '-' {
+				return errors.Errorf("invalid account name %q: invalid character %q", name, c)
+			}
+		}
+	}
+	return nil
+}
+
+func isLower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
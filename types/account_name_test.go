@@ -0,0 +1,22 @@
+package types
+
+import (
+	// Stdlib
+	"testing"
+)
+
+func TestValidateAccountName(t *testing.T) {
+	for _, name := range []string{"abc", "alice", "alice-bob", "alice.bob", "a1b", "abc.def.gh1", "sixteen-chars-ok"} {
+		if err := ValidateAccountName(name); err != nil {
+			t.Errorf("%v: %v", name, err)
+		}
+	}
+
+	for _, name := range []string{
+		"", "ab", "seventeen-chars-x", "Alice", "1abc", "abc-", "ab.cde", "abc.", ".abc", "abc..def", "al_ice", "al ice",
+	} {
+		if err := ValidateAccountName(name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+}
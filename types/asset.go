@@ -0,0 +1,307 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	SymbolSCR   = "SCR"
+	SymbolSP    = "SP"
+	SymbolVESTS = "VESTS"
+
+	// ScorumPrecision is the number of decimal places of SCR and SP amounts.
+	ScorumPrecision = 9
+)
+
+const (
+	// maxAssetPrecision is the highest precision an int64 amount can represent.
+	maxAssetPrecision = 18
+
+	// maxSymbolLength is the length of the symbol field in the binary form.
+	maxSymbolLength = 7
+)
+
+// Asset represents an amount of the given asset, e.g. "10.000000000 SCR".
+//
+// The amount is stored as an integer number of the smallest units,
+// i.e. 10.000000000 SCR is stored as Amount 10000000000 with Precision 9.
+type Asset struct {
+	Amount    int64
+	Precision uint8
+	Symbol    string
+}
+
+// NewAsset returns a new asset. The amount is in the smallest units.
+func NewAsset(amount int64, precision uint8, symbol string) *Asset {
+	return &Asset{Amount: amount, Precision: precision, Symbol: symbol}
+}
+
+// ParseAsset parses an asset string, e.g. "10.000000000 SCR".
+// The precision is the number of decimal places present in the string.
+func ParseAsset(s string) (*Asset, error) {
+	parts := strings.Split(s, " ")
+	if len(parts) != 2 {
+		return nil, errors.Errorf("invalid asset: %q", s)
+	}
+	number, symbol := parts[0], parts[1]
+
+	if err := validateSymbol(symbol); err != nil {
+		return nil, errors.Wrapf(err, "invalid asset: %q", s)
+	}
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	integer, fraction := number, ""
+	if i := strings.Index(number, "."); i != -1 {
+		integer, fraction = number[:i], number[i+1:]
+		if fraction == "" {
+			return nil, errors.Errorf("invalid asset: %q", s)
+		}
+	}
+	if integer == "" || !isDigits(integer) || !isDigits(fraction) {
+		return nil, errors.Errorf("invalid asset: %q", s)
+	}
+	if len(fraction) > maxAssetPrecision {
+		return nil, errors.Errorf("invalid asset: %q: precision too high", s)
+	}
+
+	amount, err := strconv.ParseInt(sign+integer+fraction, 10, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid asset: %q", s)
+	}
+
+	return &Asset{Amount: amount, Precision: uint8(len(fraction)), Symbol: symbol}, nil
+}
+
+// MustParseAsset is like ParseAsset, but it panics on error.
+// It is meant to be used for constants.
+func MustParseAsset(s string) *Asset {
+	asset, err := ParseAsset(s)
+	if err != nil {
+		panic(err)
+	}
+	return asset
+}
+
+func validateSymbol(symbol string) error {
+	if symbol == "" || len(symbol) > maxSymbolLength {
+		return errors.Errorf("symbol must be 1 to %v characters long", maxSymbolLength)
+	}
+	for _, c := range symbol {
+		if c < 'A' || c > 'Z' {
+			return errors.Errorf("symbol must consist of upper case letters: %v", symbol)
+		}
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the asset the same way the node does, e.g. "10.000000000 SCR".
+func (asset *Asset) String() string {
+	// Converting to uint64 handles math.MinInt64 correctly.
+	abs := uint64(asset.Amount)
+	if asset.Amount < 0 {
+		abs = uint64(-asset.Amount)
+	}
+
+	digits := strconv.FormatUint(abs, 10)
+	precision := int(asset.Precision)
+	if len(digits) <= precision {
+		digits = strings.Repeat("0", precision-len(digits)+1) + digits
+	}
+
+	var b strings.Builder
+	if asset.Amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-precision])
+	if precision != 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[len(digits)-precision:])
+	}
+	b.WriteByte(' ')
+	b.WriteString(asset.Symbol)
+	return b.String()
+}
+
+// IsZero returns true when the amount is zero.
+func (asset *Asset) IsZero() bool {
+	return asset.Amount == 0
+}
+
+// Add returns the sum of the assets.
+// An error is returned when the assets differ in symbol or precision
+// or when the result overflows.
+func (asset *Asset) Add(other *Asset) (*Asset, error) {
+	if err := asset.checkCompatible(other); err != nil {
+		return nil, err
+	}
+
+	sum := asset.Amount + other.Amount
+	if (other.Amount > 0 && sum < asset.Amount) || (other.Amount < 0 && sum > asset.Amount) {
+		return nil, errors.Errorf("asset overflow: %v + %v", asset, other)
+	}
+	return &Asset{Amount: sum, Precision: asset.Precision, Symbol: asset.Symbol}, nil
+}
+
+// Sub returns the difference of the assets.
+// An error is returned when the assets differ in symbol or precision
+// or when the result overflows.
+func (asset *Asset) Sub(other *Asset) (*Asset, error) {
+	if err := asset.checkCompatible(other); err != nil {
+		return nil, err
+	}
+
+	diff := asset.Amount - other.Amount
+	if (other.Amount > 0 && diff > asset.Amount) || (other.Amount < 0 && diff < asset.Amount) {
+		return nil, errors.Errorf("asset overflow: %v - %v", asset, other)
+	}
+	return &Asset{Amount: diff, Precision: asset.Precision, Symbol: asset.Symbol}, nil
+}
+
+// Cmp compares the assets and returns -1, 0 or +1
+// when the asset is less than, equal to or greater than other.
+// An error is returned when the assets differ in symbol or precision.
+func (asset *Asset) Cmp(other *Asset) (int, error) {
+	if err := asset.checkCompatible(other); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case asset.Amount < other.Amount:
+		return -1, nil
+	case asset.Amount > other.Amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (asset *Asset) checkCompatible(other *Asset) error {
+	if asset.Symbol != other.Symbol {
+		return errors.Errorf("asset symbol mismatch: %v and %v", asset.Symbol, other.Symbol)
+	}
+	if asset.Precision != other.Precision {
+		return errors.Errorf("asset precision mismatch: %v and %v", asset, other)
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (asset *Asset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(asset.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (asset *Asset) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal asset: %v", string(data))
+	}
+
+	parsed, err := ParseAsset(s)
+	if err != nil {
+		return err
+	}
+	*asset = *parsed
+	return nil
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+//
+// The asset is serialized as the amount (int64) followed by the precision (1 byte)
+// and the symbol padded with zero bytes to 7 bytes.
+func (asset *Asset) MarshalTransaction(encoder *transaction.Encoder) error {
+	if asset == nil {
+		return errors.New("asset not set")
+	}
+	if err := validateSymbol(asset.Symbol); err != nil {
+		return err
+	}
+
+	symbol := make([]byte, maxSymbolLength)
+	copy(symbol, asset.Symbol)
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeNumber(asset.Amount)
+	enc.EncodeNumber(asset.Precision)
+	enc.EncodeRaw(symbol)
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (asset *Asset) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var (
+		amount    int64
+		precision uint8
+		symbol    = make([]byte, maxSymbolLength)
+	)
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeNumber(&amount)
+	dec.DecodeNumber(&precision)
+	dec.DecodeRaw(symbol)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+
+	if precision > maxAssetPrecision {
+		return errors.Errorf("invalid asset precision: %v", precision)
+	}
+	name := string(bytes.TrimRight(symbol, "\x00"))
+	if err := validateSymbol(name); err != nil {
+		return err
+	}
+
+	*asset = Asset{Amount: amount, Precision: precision, Symbol: name}
+	return nil
+}
+
+// Price is the ratio of two assets, e.g. a feed price.
+type Price struct {
+	Base  *Asset `json:"base"`
+	Quote *Asset `json:"quote"`
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (price *Price) MarshalTransaction(encoder *transaction.Encoder) error {
+	if price == nil {
+		return errors.New("price not set")
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(price.Base)
+	enc.Encode(price.Quote)
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (price *Price) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	price.Base, price.Quote = &Asset{}, &Asset{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(price.Base)
+	dec.Decode(price.Quote)
+	return dec.Err()
+}
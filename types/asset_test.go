@@ -0,0 +1,160 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+func TestParseAsset(t *testing.T) {
+	tests := []struct {
+		input     string
+		amount    int64
+		precision uint8
+		symbol    string
+	}{
+		{"10.000000000 SCR", 10000000000, 9, SymbolSCR},
+		{"0.000000001 SP", 1, 9, SymbolSP},
+		{"-1.500 SCR", -1500, 3, SymbolSCR},
+		{"123456.789000 VESTS", 123456789000, 6, SymbolVESTS},
+		{"7 SCR", 7, 0, SymbolSCR},
+	}
+
+	for _, test := range tests {
+		asset, err := ParseAsset(test.input)
+		if err != nil {
+			t.Errorf("%v: %v", test.input, err)
+			continue
+		}
+		if asset.Amount != test.amount || asset.Precision != test.precision || asset.Symbol != test.symbol {
+			t.Errorf("%v: got %+v", test.input, asset)
+		}
+		if got := asset.String(); got != test.input {
+			t.Errorf("%v: formatted as %v", test.input, got)
+		}
+	}
+}
+
+func TestParseAsset_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"10",
+		"10.000 ",
+		"10.000 scr",
+		"10.000 TOOLONGSYM",
+		"10. SCR",
+		".5 SCR",
+		"1,5 SCR",
+		"10.000  SCR",
+		"99999999999999999999 SCR",
+	} {
+		if asset, err := ParseAsset(input); err == nil {
+			t.Errorf("%q: expected an error, got %v", input, asset)
+		}
+	}
+}
+
+func TestAsset_String(t *testing.T) {
+	tests := []struct {
+		asset    *Asset
+		expected string
+	}{
+		{NewAsset(1, 9, SymbolSCR), "0.000000001 SCR"},
+		{NewAsset(-1, 3, SymbolSCR), "-0.001 SCR"},
+		{NewAsset(0, 3, SymbolSP), "0.000 SP"},
+		{NewAsset(math.MinInt64, 9, SymbolSCR), "-9223372036.854775808 SCR"},
+	}
+
+	for _, test := range tests {
+		if got := test.asset.String(); got != test.expected {
+			t.Errorf("expected %v, got %v", test.expected, got)
+		}
+	}
+}
+
+func TestAsset_Arithmetic(t *testing.T) {
+	a := MustParseAsset("1.500000000 SCR")
+	b := MustParseAsset("0.250000000 SCR")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "1.750000000 SCR" {
+		t.Errorf("expected 1.750000000 SCR, got %v, %v", sum, err)
+	}
+
+	diff, err := b.Sub(a)
+	if err != nil || diff.String() != "-1.250000000 SCR" {
+		t.Errorf("expected -1.250000000 SCR, got %v, %v", diff, err)
+	}
+
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("expected 1, got %v, %v", cmp, err)
+	}
+
+	if _, err := a.Add(MustParseAsset("1.000000000 SP")); err == nil {
+		t.Error("expected a symbol mismatch error")
+	}
+	if _, err := a.Cmp(MustParseAsset("1.000 SCR")); err == nil {
+		t.Error("expected a precision mismatch error")
+	}
+
+	max := NewAsset(math.MaxInt64, 9, SymbolSCR)
+	if _, err := max.Add(NewAsset(1, 9, SymbolSCR)); err == nil {
+		t.Error("expected an overflow error")
+	}
+	min := NewAsset(math.MinInt64, 9, SymbolSCR)
+	if _, err := min.Sub(NewAsset(1, 9, SymbolSCR)); err == nil {
+		t.Error("expected an overflow error")
+	}
+}
+
+func TestAsset_JSON(t *testing.T) {
+	var op TransferOperation
+	if err := json.Unmarshal([]byte(`{"from":"alice","to":"bob","amount":"1.000000000 SCR","memo":""}`), &op); err != nil {
+		t.Fatal(err)
+	}
+	if op.Amount.Amount != 1000000000 || op.Amount.Symbol != SymbolSCR {
+		t.Errorf("unexpected amount: %+v", op.Amount)
+	}
+
+	data, err := json.Marshal(op.Amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `"1.000000000 SCR"` {
+		t.Errorf("unexpected JSON: %v", string(data))
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":"1.0.0 SCR"}`), &op); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestTransferOperation_MarshalTransaction(t *testing.T) {
+	op := &TransferOperation{
+		From:   "foo",
+		To:     "bar",
+		Amount: MustParseAsset("1.000 STEEM"),
+	}
+
+	// The amount is e803000000000000 (1000), 03 (precision), STEEM padded to 7 bytes.
+	expected := "0203666f6f03626172e80300000000000003535445454d000000"
+
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b).Encode(op); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	op.Amount = nil
+	if err := transaction.NewEncoder(&b).Encode(op); err == nil {
+		t.Error("expected an error for a missing amount")
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// BigInt represents an integer of arbitrary size, e.g. uint128 or uint256 values
+// like total_reward_shares2 or children_rshares2.
+//
+// The node serializes these either as JSON numbers or as decimal strings,
+// both forms are accepted. BigInt is marshalled as a decimal string
+// so that the value survives JSON parsers using floating point numbers.
+type BigInt struct {
+	*big.Int
+}
+
+// NewBigInt returns a new BigInt set to x.
+func NewBigInt(x int64) *BigInt {
+	return &BigInt{big.NewInt(x)}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (num *BigInt) UnmarshalJSON(data []byte) error {
+	value, err := unmarshalBigInt(data)
+	if err != nil {
+		return err
+	}
+	num.Int = value
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// The receiver is a value, so BigInt and *BigInt fields are marshalled the same way.
+func (num BigInt) MarshalJSON() ([]byte, error) {
+	if num.Int == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(num.Int.String())
+}
+
+// unmarshalBigInt parses an integer encoded as a JSON number or a decimal string.
+// JSON null is turned into nil.
+func unmarshalBigInt(data []byte) (*big.Int, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	s := string(data)
+	if len(data) >= 2 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, errors.Wrapf(err, "types: failed to unmarshal integer: %v", string(data))
+		}
+	}
+
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, errors.Errorf("types: failed to unmarshal integer: %v", string(data))
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	// Stdlib
+	"encoding/json"
+	"testing"
+)
+
+func TestBigInt_JSON(t *testing.T) {
+	const huge = "340282366920938463463374607431768211455"
+
+	for _, input := range []string{`"` + huge + `"`, huge} {
+		var num BigInt
+		if err := json.Unmarshal([]byte(input), &num); err != nil {
+			t.Fatalf("%v: %v", input, err)
+		}
+		if num.String() != huge {
+			t.Errorf("%v: got %v", input, num.String())
+		}
+
+		data, err := json.Marshal(&num)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `"`+huge+`"` {
+			t.Errorf("%v: unexpected JSON: %v", input, string(data))
+		}
+	}
+
+	var value struct {
+		Num *BigInt `json:"num"`
+	}
+	if err := json.Unmarshal([]byte(`{"num":"-42"}`), &value); err != nil {
+		t.Fatal(err)
+	}
+	if value.Num.Int64() != -42 {
+		t.Errorf("unexpected value: %v", value.Num)
+	}
+
+	for _, input := range []string{`""`, `"abc"`, `1.5`, `"1e3"`, `true`} {
+		var num BigInt
+		if err := json.Unmarshal([]byte(input), &num); err == nil {
+			t.Errorf("%v: expected an error, got %v", input, num)
+		}
+	}
+}
+
+func TestBigInt_MarshalJSON_Value(t *testing.T) {
+	value := struct {
+		Num    BigInt  `json:"num"`
+		Ptr    *BigInt `json:"ptr"`
+		Unset  BigInt  `json:"unset"`
+		NilPtr *BigInt `json:"nil_ptr"`
+	}{
+		Num: *NewBigInt(42),
+		Ptr: NewBigInt(42),
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"num":"42","ptr":"42","unset":null,"nil_ptr":null}`
+	if string(data) != expected {
+		t.Errorf("expected %v, got %v", expected, string(data))
+	}
+}
+
+func TestInt_UnmarshalJSON(t *testing.T) {
+	const huge = "-92233720368547758080"
+
+	for _, input := range []string{huge, `"` + huge + `"`} {
+		var num Int
+		if err := json.Unmarshal([]byte(input), &num); err != nil {
+			t.Fatalf("%v: %v", input, err)
+		}
+		if num.String() != huge {
+			t.Errorf("%v: got %v", input, num.String())
+		}
+	}
+
+	var num Int
+	if err := json.Unmarshal([]byte(`null`), &num); err != nil || num.Sign() != 0 {
+		t.Errorf("expected zero, got %v, %v", num.Int, err)
+	}
+}
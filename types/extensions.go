@@ -0,0 +1,390 @@
+package types
+
+import (
+	// Stdlib
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// extensionSet lists the extension types allowed in a particular extensions list.
+//
+// An extensions list is serialized as a list of static_variant values,
+// i.e. every extension is the position of its type in the set
+// followed by the extension itself. In JSON every extension is [position, extension].
+type extensionSet struct {
+	name  string
+	types []reflect.Type
+}
+
+func newExtensionSet(name string, extensions ...interface{}) *extensionSet {
+	set := &extensionSet{name: name}
+	for _, ext := range extensions {
+		set.types = append(set.types, reflect.TypeOf(ext))
+	}
+	return set
+}
+
+func (set *extensionSet) index(ext interface{}) (int, error) {
+	t := reflect.TypeOf(ext)
+	for i, v := range set.types {
+		if v == t {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf("%v: unsupported extension type: %T", set.name, ext)
+}
+
+func (set *extensionSet) new(index uint64) (interface{}, error) {
+	if index >= uint64(len(set.types)) {
+		return nil, errors.Errorf("%v: unknown extension type: %v", set.name, index)
+	}
+	return reflect.New(set.types[index].Elem()).Interface(), nil
+}
+
+func (set *extensionSet) marshalJSON(extensions []interface{}) ([]byte, error) {
+	list := make([][]interface{}, 0, len(extensions))
+	for _, ext := range extensions {
+		index, err := set.index(ext)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, []interface{}{index, ext})
+	}
+	return json.Marshal(list)
+}
+
+func (set *extensionSet) unmarshalJSON(data []byte) ([]interface{}, error) {
+	var list [][]json.RawMessage
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, errors.Wrapf(err, "%v: failed to unmarshal extensions: %v", set.name, string(data))
+	}
+
+	extensions := make([]interface{}, 0, len(list))
+	for _, item := range list {
+		if len(item) != 2 {
+			return nil, errors.Errorf("%v: invalid extension: %v", set.name, item)
+		}
+
+		var index uint64
+		if err := json.Unmarshal(item[0], &index); err != nil {
+			return nil, errors.Wrapf(err, "%v: invalid extension type: %v", set.name, string(item[0]))
+		}
+		ext, err := set.new(index)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(item[1], ext); err != nil {
+			return nil, errors.Wrapf(err, "%v: failed to unmarshal extension %v", set.name, index)
+		}
+		extensions = append(extensions, ext)
+	}
+	return extensions, nil
+}
+
+func (set *extensionSet) encode(encoder *transaction.Encoder, extensions []interface{}) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(len(extensions)))
+	for _, ext := range extensions {
+		index, err := set.index(ext)
+		if err != nil {
+			return err
+		}
+		enc.EncodeUVarint(uint64(index))
+		enc.Encode(ext)
+	}
+	return enc.Err()
+}
+
+func (set *extensionSet) decode(decoder *transaction.Decoder) ([]interface{}, error) {
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%v: failed to decode extensions", set.name)
+	}
+
+	var extensions []interface{}
+	for i := uint64(0); i < length; i++ {
+		index, err := decoder.DecodeUVarint()
+		if err != nil {
+			return nil, errors.Wrapf(err, "%v: failed to decode extension type", set.name)
+		}
+		ext, err := set.new(index)
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(ext); err != nil {
+			return nil, errors.Wrapf(err, "%v: failed to decode extension %v", set.name, index)
+		}
+		extensions = append(extensions, ext)
+	}
+	return extensions, nil
+}
+
+// transactionExtensionSet is empty, the node does not define any transaction extensions yet.
+var transactionExtensionSet = newExtensionSet("transaction")
+
+// TransactionExtensions are the extensions of a transaction.
+// There are no extension types defined yet, so the list must be empty.
+type TransactionExtensions []interface{}
+
+// MarshalJSON implements json.Marshaler.
+func (exts TransactionExtensions) MarshalJSON() ([]byte, error) {
+	return transactionExtensionSet.marshalJSON(exts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (exts *TransactionExtensions) UnmarshalJSON(data []byte) error {
+	list, err := transactionExtensionSet.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (exts TransactionExtensions) MarshalTransaction(encoder *transaction.Encoder) error {
+	return transactionExtensionSet.encode(encoder, exts)
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (exts *TransactionExtensions) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	list, err := transactionExtensionSet.decode(decoder)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// commentOptionsExtensionSet lists the comment_options extensions in the node order.
+var commentOptionsExtensionSet = newExtensionSet("comment_options",
+	&CommentPayoutBeneficiaries{},
+)
+
+// CommentOptionsExtensions are the extensions of comment_options.
+// *CommentPayoutBeneficiaries is the only extension type.
+type CommentOptionsExtensions []interface{}
+
+// MarshalJSON implements json.Marshaler.
+func (exts CommentOptionsExtensions) MarshalJSON() ([]byte, error) {
+	return commentOptionsExtensionSet.marshalJSON(exts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (exts *CommentOptionsExtensions) UnmarshalJSON(data []byte) error {
+	list, err := commentOptionsExtensionSet.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (exts CommentOptionsExtensions) MarshalTransaction(encoder *transaction.Encoder) error {
+	return commentOptionsExtensionSet.encode(encoder, exts)
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (exts *CommentOptionsExtensions) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	list, err := commentOptionsExtensionSet.decode(decoder)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// blockHeaderExtensionSet lists the block header extensions in the node order.
+var blockHeaderExtensionSet = newExtensionSet("block_header",
+	&VoidExtension{},
+	&Version{},
+	&HardforkVersionVote{},
+)
+
+// BlockHeaderExtensions are the extensions of a block header.
+// The extension types are *VoidExtension, *Version, which is the version
+// of the node producing the block, and *HardforkVersionVote.
+type BlockHeaderExtensions []interface{}
+
+// MarshalJSON implements json.Marshaler.
+func (exts BlockHeaderExtensions) MarshalJSON() ([]byte, error) {
+	return blockHeaderExtensionSet.marshalJSON(exts)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (exts *BlockHeaderExtensions) UnmarshalJSON(data []byte) error {
+	list, err := blockHeaderExtensionSet.unmarshalJSON(data)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (exts BlockHeaderExtensions) MarshalTransaction(encoder *transaction.Encoder) error {
+	return blockHeaderExtensionSet.encode(encoder, exts)
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (exts *BlockHeaderExtensions) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	list, err := blockHeaderExtensionSet.decode(decoder)
+	if err != nil {
+		return err
+	}
+	*exts = list
+	return nil
+}
+
+// VoidExtension is the void_t extension, it carries no data.
+type VoidExtension struct{}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (ext *VoidExtension) MarshalTransaction(encoder *transaction.Encoder) error {
+	return nil
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (ext *VoidExtension) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	return nil
+}
+
+// HardforkVersionVote is the block header extension a witness uses
+// to vote for the next hardfork and the time it is to be applied.
+type HardforkVersionVote struct {
+	HardforkVersion *Version `json:"hf_version"`
+	HardforkTime    *Time    `json:"hf_time"`
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (ext *HardforkVersionVote) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ext.HardforkVersion)
+	enc.Encode(ext.HardforkTime)
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (ext *HardforkVersionVote) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	ext.HardforkVersion = &Version{}
+	ext.HardforkTime = &Time{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(ext.HardforkVersion)
+	dec.Decode(ext.HardforkTime)
+	return dec.Err()
+}
+
+const (
+	// MaxBeneficiaries is the maximum number of beneficiaries of a comment.
+	MaxBeneficiaries = 8
+
+	// BeneficiaryWeightTotal is 100% as used for beneficiary weights.
+	BeneficiaryWeightTotal = 10000
+)
+
+// BeneficiaryRoute routes a part of the author reward to another account.
+// The weight is in hundredths of a percent, i.e. 10000 means 100%.
+type BeneficiaryRoute struct {
+	Account string `json:"account"`
+	Weight  uint16 `json:"weight"`
+}
+
+// CommentPayoutBeneficiaries is the comment_options extension setting the beneficiaries.
+//
+// The node requires the beneficiaries to be sorted by account,
+// so they are sorted when the extension is encoded.
+type CommentPayoutBeneficiaries struct {
+	Beneficiaries []*BeneficiaryRoute `json:"beneficiaries"`
+}
+
+// Validate checks the beneficiaries the same way the node does, i.e. there must be
+// at least one and at most MaxBeneficiaries unique accounts and the weights
+// must not exceed BeneficiaryWeightTotal in total.
+func (ext *CommentPayoutBeneficiaries) Validate() error {
+	switch {
+	case len(ext.Beneficiaries) == 0:
+		return errors.New("beneficiaries: at least one beneficiary must be specified")
+	case len(ext.Beneficiaries) > MaxBeneficiaries:
+		return errors.Errorf("beneficiaries: at most %v beneficiaries allowed, got %v",
+			MaxBeneficiaries, len(ext.Beneficiaries))
+	}
+
+	var total uint32
+	accounts := make(map[string]bool, len(ext.Beneficiaries))
+	for _, route := range ext.Beneficiaries {
+		if route == nil || route.Account == "" {
+			return errors.New("beneficiaries: account not set")
+		}
+		if accounts[route.Account] {
+			return errors.Errorf("beneficiaries: duplicate account: %v", route.Account)
+		}
+		accounts[route.Account] = true
+		total += uint32(route.Weight)
+	}
+	if total > BeneficiaryWeightTotal {
+		return errors.Errorf("beneficiaries: total weight %v exceeds %v", total, BeneficiaryWeightTotal)
+	}
+	return nil
+}
+
+func (ext *CommentPayoutBeneficiaries) sorted() []*BeneficiaryRoute {
+	routes := append([]*BeneficiaryRoute(nil), ext.Beneficiaries...)
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Account < routes[j].Account
+	})
+	return routes
+}
+
+// MarshalJSON implements json.Marshaler.
+// The beneficiaries are sorted the same way as in the binary form.
+func (ext *CommentPayoutBeneficiaries) MarshalJSON() ([]byte, error) {
+	if err := ext.Validate(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(struct {
+		Beneficiaries []*BeneficiaryRoute `json:"beneficiaries"`
+	}{ext.sorted()})
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (ext *CommentPayoutBeneficiaries) MarshalTransaction(encoder *transaction.Encoder) error {
+	if err := ext.Validate(); err != nil {
+		return err
+	}
+
+	routes := ext.sorted()
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(len(routes)))
+	for _, route := range routes {
+		enc.Encode(route.Account)
+		enc.Encode(route.Weight)
+	}
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (ext *CommentPayoutBeneficiaries) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return errors.Wrap(err, "beneficiaries: failed to decode length")
+	}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	ext.Beneficiaries = nil
+	for i := uint64(0); i < length && dec.Err() == nil; i++ {
+		var route BeneficiaryRoute
+		dec.Decode(&route.Account)
+		dec.Decode(&route.Weight)
+		ext.Beneficiaries = append(ext.Beneficiaries, &route)
+	}
+	return dec.Err()
+}
@@ -0,0 +1,190 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+func TestCommentOptionsOperation_Beneficiaries(t *testing.T) {
+	t.Parallel()
+
+	// The beneficiaries are not sorted on purpose.
+	beneficiaries := CommentOptionsExtensions{
+		&CommentPayoutBeneficiaries{Beneficiaries: []*BeneficiaryRoute{
+			{Account: "carol", Weight: 500},
+			{Account: "bob", Weight: 1000},
+		}},
+	}
+	expected := []*BeneficiaryRoute{{Account: "bob", Weight: 1000}, {Account: "carol", Weight: 500}}
+	const beneficiariesHex = "01000203626f62e803056361726f6cf401"
+
+	tests := []struct {
+		catalog  *OpCatalog
+		op       *CommentOptionsOperation
+		expected string
+	}{
+		{
+			ScorumOpCatalog,
+			&CommentOptionsOperation{
+				Author:               "alice",
+				Permlink:             "hello",
+				MaxAcceptedPayout:    NewAsset(1000000000, ScorumPrecision, SymbolSCR),
+				AllowVotes:           true,
+				AllowCurationRewards: true,
+				Extensions:           beneficiaries,
+			},
+			"0d05616c6963650568656c6c6f00ca9a3b000000000953435200000000" + "0101" + beneficiariesHex,
+		},
+		{
+			SteemOpCatalog,
+			&CommentOptionsOperation{
+				Author:               "alice",
+				Permlink:             "hello",
+				MaxAcceptedPayout:    NewAsset(1000000, 3, "SBD"),
+				PercentSteemDollars:  10000,
+				AllowVotes:           true,
+				AllowCurationRewards: true,
+				Extensions:           beneficiaries,
+			},
+			"1305616c6963650568656c6c6f40420f00000000000353424400000000" + "1027" + "0101" + beneficiariesHex,
+		},
+	}
+
+	for _, test := range tests {
+		catalog := transaction.SetCatalog(test.catalog)
+		testRoundTrip(t, test.op, test.expected, catalog)
+
+		raw, _ := hex.DecodeString(test.expected)
+		decoded, err := DecodeOperation(transaction.NewDecoder(bytes.NewReader(raw), catalog))
+		if err != nil {
+			t.Fatal(err)
+		}
+		exts := decoded.(*CommentOptionsOperation).Extensions
+		if ext, ok := exts[0].(*CommentPayoutBeneficiaries); !ok || !reflect.DeepEqual(ext.Beneficiaries, expected) {
+			t.Errorf("%v: unexpected extensions: %v", test.catalog.Name(), exts)
+		}
+	}
+
+	data, err := json.Marshal(beneficiaries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `[[0,{"beneficiaries":[{"account":"bob","weight":1000},{"account":"carol","weight":500}]}]]`
+	if string(data) != expectedJSON {
+		t.Errorf("expected %v, got %v", expectedJSON, string(data))
+	}
+
+	var exts CommentOptionsExtensions
+	if err := json.Unmarshal(data, &exts); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exts[0].(*CommentPayoutBeneficiaries).Beneficiaries, expected) {
+		t.Errorf("unexpected extensions: %v", exts)
+	}
+}
+
+func TestCommentPayoutBeneficiaries_Validate(t *testing.T) {
+	tooMany := make([]*BeneficiaryRoute, MaxBeneficiaries+1)
+	for i := range tooMany {
+		tooMany[i] = &BeneficiaryRoute{Account: string(rune('a'+i)) + "lice", Weight: 1}
+	}
+
+	for _, routes := range [][]*BeneficiaryRoute{
+		nil,
+		tooMany,
+		{{Account: "", Weight: 1}},
+		{{Account: "bob", Weight: 1}, {Account: "bob", Weight: 2}},
+		{{Account: "bob", Weight: 5000}, {Account: "carol", Weight: 5001}},
+	} {
+		ext := &CommentPayoutBeneficiaries{Beneficiaries: routes}
+		if err := ext.Validate(); err == nil {
+			t.Errorf("%v: expected an error", routes)
+		}
+		if err := transaction.NewEncoder(&bytes.Buffer{}).Encode(ext); err == nil {
+			t.Errorf("%v: expected an encoding error", routes)
+		}
+	}
+
+	ext := &CommentPayoutBeneficiaries{Beneficiaries: []*BeneficiaryRoute{
+		{Account: "bob", Weight: 5000}, {Account: "carol", Weight: 5000},
+	}}
+	if err := ext.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBlockHeaderExtensions(t *testing.T) {
+	input := `[[0,{}],[1,"0.19.2"],[2,{"hf_version":"0.19.0","hf_time":"2017-06-20T15:00:00"}]]`
+	expectedHex := "0300" + "0102001300" + "020000130070384959"
+
+	var exts BlockHeaderExtensions
+	if err := json.Unmarshal([]byte(input), &exts); err != nil {
+		t.Fatal(err)
+	}
+	if version := exts[1].(*Version); version.String() != "0.19.2" {
+		t.Errorf("unexpected version: %v", version)
+	}
+	vote := exts[2].(*HardforkVersionVote)
+	if vote.HardforkVersion.String() != "0.19.0" || vote.HardforkTime.Unix() != 1497970800 {
+		t.Errorf("unexpected hardfork vote: %v %v", vote.HardforkVersion, vote.HardforkTime)
+	}
+
+	data, err := json.Marshal(exts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != input {
+		t.Errorf("expected %v, got %v", input, string(data))
+	}
+
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b).Encode(exts); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != expectedHex {
+		t.Fatalf("expected %v, got %v", expectedHex, got)
+	}
+
+	var decoded BlockHeaderExtensions
+	if err := transaction.NewDecoder(&b).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, exts) {
+		t.Errorf("expected %v, got %v", exts, decoded)
+	}
+
+	for _, data := range []string{`[[1,"0.19"]]`, `[[2,{"hf_version":1}]]`, `[[3,{}]]`} {
+		if err := json.Unmarshal([]byte(data), &exts); err == nil {
+			t.Errorf("%v: expected an error", data)
+		}
+	}
+}
+
+func TestExtensions_Invalid(t *testing.T) {
+	var exts CommentOptionsExtensions
+	for _, data := range []string{`[[1,{}]]`, `[[0]]`, `{}`} {
+		if err := json.Unmarshal([]byte(data), &exts); err == nil {
+			t.Errorf("%v: expected an error", data)
+		}
+	}
+
+	var txExts TransactionExtensions
+	if err := json.Unmarshal([]byte(`[]`), &txExts); err != nil {
+		t.Error(err)
+	}
+	if err := json.Unmarshal([]byte(`[[0,{}]]`), &txExts); err == nil {
+		t.Error("expected an error for an unknown transaction extension")
+	}
+
+	tx := TransactionExtensions{&CommentPayoutBeneficiaries{}}
+	if err := transaction.NewEncoder(&bytes.Buffer{}).Encode(tx); err == nil {
+		t.Error("expected an error for an unsupported transaction extension")
+	}
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+var (
+	testKey1 = MustParsePublicKey(testPublicKey, "STM")
+	testKey2 = func() *PublicKey {
+		key, err := NewPublicKey(append([]byte{0x02}, bytes.Repeat([]byte{0x11}, 32)...), "STM")
+		if err != nil {
+			panic(err)
+		}
+		return key
+	}()
+
+	// testAuthority is not sorted on purpose, the auths are sorted when encoded.
+	testAuthority = &Authority{
+		WeightThreshold: 1,
+		AccountAuths:    []*Auth{{Key: "carol", Check: 1}, {Key: "bob", Check: 2}},
+		KeyAuths:        []*KeyAuth{{Key: testKey1, Check: 1}, {Key: testKey2, Check: 1}},
+	}
+)
+
+// testKeyAuthority returns the authority satisfied by the given key alone.
+func testKeyAuthority(key *PublicKey) *Authority {
+	return &Authority{WeightThreshold: 1, KeyAuths: []*KeyAuth{{Key: key, Check: 1}}}
+}
+
+// testRoundTrip checks that op encodes into expectedHex and that decoding
+// and encoding the result again yields the same bytes.
+// The options are passed into the encoder and the decoder, e.g. transaction.SetCatalog.
+func testRoundTrip(t *testing.T, op Operation, expectedHex string, options ...transaction.Option) {
+	t.Helper()
+
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b, options...).Encode(op); err != nil {
+		t.Errorf("%v: %v", op.Type(), err)
+		return
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != expectedHex {
+		t.Errorf("%v: expected %v, got %v", op.Type(), expectedHex, got)
+		return
+	}
+
+	decoded, err := DecodeOperation(transaction.NewDecoder(&b, options...))
+	if err != nil {
+		t.Errorf("%v: %v", op.Type(), err)
+		return
+	}
+	if b.Len() != 0 {
+		t.Errorf("%v: %v bytes not decoded", op.Type(), b.Len())
+	}
+	if err := transaction.NewEncoder(&b, options...).Encode(decoded); err != nil {
+		t.Errorf("%v: %v", op.Type(), err)
+		return
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != expectedHex {
+		t.Errorf("%v: round trip: expected %v, got %v", op.Type(), expectedHex, got)
+	}
+}
@@ -58,7 +58,16 @@ func (num *Int8) UnmarshalJSON(data []byte) error {
 }
 
 func (num Int8) MarshalTransaction(encoder *transaction.Encoder) error {
-	return encoder.EncodeNumber(int(num))
+	return encoder.EncodeNumber(int8(num))
+}
+
+func (num *Int8) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int8
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int8(v)
+	return nil
 }
 
 type Int16 int16
@@ -77,6 +86,15 @@ func (num Int16) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int16(num))
 }
 
+func (num *Int16) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int16
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int16(v)
+	return nil
+}
+
 type Int32 int32
 
 func (num *Int32) UnmarshalJSON(data []byte) error {
@@ -93,6 +111,15 @@ func (num Int32) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int32(num))
 }
 
+func (num *Int32) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int32(v)
+	return nil
+}
+
 type Int64 int64
 
 func (num *Int64) UnmarshalJSON(data []byte) error {
@@ -108,3 +135,12 @@ func (num *Int64) UnmarshalJSON(data []byte) error {
 func (num Int64) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(int64(num))
 }
+
+func (num *Int64) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v int64
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = Int64(v)
+	return nil
+}
@@ -1,7 +1,6 @@
 package types
 
 import (
-	"encoding/json"
 	"math/big"
 )
 
@@ -9,22 +8,17 @@ type Int struct {
 	*big.Int
 }
 
+// UnmarshalJSON accepts both JSON numbers and decimal strings of any size.
+// null is treated as zero, the same way the previous int64-based implementation did it,
+// use BigInt to tell null apart.
 func (num *Int) UnmarshalJSON(data []byte) error {
-	if data[0] == '"' {
-		data = data[1:]
-		data = data[:len(data)-1]
-		var value big.Int
-		if err := json.Unmarshal(data, &value); err != nil {
-			return err
-		}
-		num.Int = &value
-		return nil
-	}
-
-	var value int64
-	if err := json.Unmarshal(data, &value); err != nil {
+	value, err := unmarshalBigInt(data)
+	if err != nil {
 		return err
 	}
-	num.Int = big.NewInt(value)
+	if value == nil {
+		value = new(big.Int)
+	}
+	num.Int = value
 	return nil
 }
@@ -0,0 +1,121 @@
+package types
+
+import (
+	// Stdlib
+	"math"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// OpCatalog maps operation types to the operation codes used in the binary form.
+//
+// The operation code is the position of the operation in the operation
+// static_variant of the chain, so it differs between chains derived from Steem.
+// Some operations shared by the chains differ in their binary layout as well,
+// e.g. witness_update carries no fee on Scorum, so the catalog selects the layout too.
+//
+// The catalog is passed to the transaction encoder and decoder using transaction.SetCatalog.
+// ScorumOpCatalog is used when no catalog is set.
+type OpCatalog struct {
+	name   string
+	types  []OpType
+	codes  map[OpType]uint16
+	layout opLayout
+}
+
+// opLayout selects the binary layout of the operations shared by Scorum and Steem.
+type opLayout int
+
+const (
+	scorumLayout opLayout = iota
+	steemLayout
+)
+
+// NewOpCatalog creates a catalog from the operation types
+// listed in the order of the operation static_variant.
+// The operations shared with Steem are serialized the way Scorum does it.
+func NewOpCatalog(name string, opTypes []OpType) *OpCatalog {
+	return newOpCatalog(name, opTypes, scorumLayout)
+}
+
+func newOpCatalog(name string, opTypes []OpType, layout opLayout) *OpCatalog {
+	catalog := &OpCatalog{
+		name:   name,
+		types:  append([]OpType(nil), opTypes...),
+		codes:  make(map[OpType]uint16, len(opTypes)),
+		layout: layout,
+	}
+	for i, opType := range opTypes {
+		catalog.codes[opType] = uint16(i)
+	}
+	return catalog
+}
+
+var (
+	// ScorumOpCatalog is the operation catalog of the Scorum blockchain.
+	ScorumOpCatalog = NewOpCatalog("scorum", scorumOpTypes)
+
+	// SteemOpCatalog is the operation catalog of the Steem blockchain.
+	SteemOpCatalog = newOpCatalog("steem", steemOpTypes, steemLayout)
+)
+
+// Extend returns a new catalog named name, which contains the operation types
+// of this catalog followed by the given ones, i.e. the operations added to the chain
+// after the last one known to this package. The catalog itself is not modified.
+// The layout of the shared operations is kept.
+func (catalog *OpCatalog) Extend(name string, opTypes ...OpType) (*OpCatalog, error) {
+	if len(catalog.types)+len(opTypes) > math.MaxUint16+1 {
+		return nil, errors.Errorf("%v: too many operation types", name)
+	}
+	for i, opType := range opTypes {
+		if _, ok := catalog.codes[opType]; ok {
+			return nil, errors.Errorf("%v: operation already present: %v", name, opType)
+		}
+		for _, previous := range opTypes[:i] {
+			if previous == opType {
+				return nil, errors.Errorf("%v: duplicate operation: %v", name, opType)
+			}
+		}
+	}
+	return newOpCatalog(name, append(append([]OpType(nil), catalog.types...), opTypes...), catalog.layout), nil
+}
+
+// Name returns the name of the catalog, e.g. "scorum".
+func (catalog *OpCatalog) Name() string {
+	return catalog.name
+}
+
+// Code returns the operation code of the given operation type.
+func (catalog *OpCatalog) Code(kind OpType) (uint16, bool) {
+	code, ok := catalog.codes[kind]
+	return code, ok
+}
+
+// Type returns the operation type associated with the given operation code.
+func (catalog *OpCatalog) Type(code uint16) (OpType, bool) {
+	if int(code) >= len(catalog.types) {
+		return "", false
+	}
+	return catalog.types[code], true
+}
+
+// opCatalogOf returns the catalog set for the encoder or the decoder,
+// ScorumOpCatalog when there is none.
+func opCatalogOf(catalog transaction.Catalog) *OpCatalog {
+	if catalog, ok := catalog.(*OpCatalog); ok && catalog != nil {
+		return catalog
+	}
+	return ScorumOpCatalog
+}
+
+func encoderOpCatalog(encoder *transaction.Encoder) *OpCatalog {
+	return opCatalogOf(encoder.Catalog())
+}
+
+func decoderOpCatalog(decoder *transaction.Decoder) *OpCatalog {
+	return opCatalogOf(decoder.Catalog())
+}
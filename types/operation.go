@@ -4,7 +4,12 @@ import (
 	// Stdlib
 	"bytes"
 	"encoding/json"
+	"math"
 	"reflect"
+	"sync"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
 
 	// Vendor
 	"github.com/pkg/errors"
@@ -12,6 +17,7 @@ import (
 
 // dataObjects keeps mapping operation type -> operation data object.
 // This is used later on to unmarshal operation data based on the operation type.
+// The map is guarded by dataObjectsLock, use RegisterOperation to modify it.
 var dataObjects = map[OpType]Operation{
 	TypeVote:                        &VoteOperation{},
 	TypeComment:                     &CommentOperation{},
@@ -69,6 +75,69 @@ var dataObjects = map[OpType]Operation{
 	TypeCommentPayoutUpdate:         &CommentPayoutUpdateOperation{},
 	TypeReturnVestingDelegation:     &ReturnVestingDelegationOperation{},
 	TypeCommentBenefactorReward:     &CommentBenefactorRewardOperation{},
+
+	TypeTransferToScorumpower:                &TransferToScorumpowerOperation{},
+	TypeWithdrawScorumpower:                  &WithdrawScorumpowerOperation{},
+	TypeAccountCreateByCommittee:             &AccountCreateByCommitteeOperation{},
+	TypeSetWithdrawScorumpowerRouteToAccount: &SetWithdrawScorumpowerRouteToAccountOperation{},
+	TypeSetWithdrawScorumpowerRouteToDevPool: &SetWithdrawScorumpowerRouteToDevPoolOperation{},
+	TypeDelegateScorumpower:                  &DelegateScorumpowerOperation{},
+	TypeCreateBudget:                         &CreateBudgetOperation{},
+	TypeCloseBudget:                          &CloseBudgetOperation{},
+	TypeProposalVote:                         &ProposalVoteOperation{},
+	TypeProposalCreate:                       &ProposalCreateOperation{},
+	TypeAtomicSwapInitiate:                   &AtomicSwapInitiateOperation{},
+	TypeAtomicSwapRedeem:                     &AtomicSwapRedeemOperation{},
+	TypeAtomicSwapRefund:                     &AtomicSwapRefundOperation{},
+	TypeProducerReward:                       &ProducerRewardOperation{},
+	TypeReturnScorumpowerDelegation:          &ReturnScorumpowerDelegationOperation{},
+}
+
+var dataObjectsLock sync.RWMutex
+
+// RegisterOperation sets the data object template used to unmarshal operations
+// of the given type. It can be used to add operations unknown to this package
+// or to replace the built-in data objects. It is safe for concurrent use.
+//
+// The template is used for JSON. To decode the binary form as well, the template
+// must implement transaction.TransactionUnmarshaller and the operation type
+// must be present in the decoder catalog. The operation code of a new
+// operation type is assigned using OpCatalog.Extend.
+//
+// The template must be a non-nil pointer and its Type must return kind.
+// The template itself is never modified, a new instance is created every time.
+func RegisterOperation(kind OpType, template Operation) error {
+	if err := checkTemplate(template); err != nil {
+		return errors.Wrapf(err, "operation %v", kind)
+	}
+	if t := template.Type(); t != kind {
+		return errors.Errorf("operation %v: template type mismatch: %v", kind, t)
+	}
+
+	dataObjectsLock.Lock()
+	dataObjects[kind] = template
+	dataObjectsLock.Unlock()
+	return nil
+}
+
+// checkTemplate makes sure a data object template is a non-nil pointer.
+func checkTemplate(template interface{}) error {
+	v := reflect.ValueOf(template)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errors.Errorf("template must be a non-nil pointer, got %T", template)
+	}
+	return nil
+}
+
+// newDataObject returns a new instance of the data object registered for the given type.
+func newDataObject(kind OpType) (Operation, bool) {
+	dataObjectsLock.RLock()
+	template, ok := dataObjects[kind]
+	dataObjectsLock.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface().(Operation), true
 }
 
 // Operation represents an operation stored in a transaction.
@@ -78,13 +147,43 @@ type Operation interface {
 
 	// Data returns the operation data as present in the operation object, element [1].
 	//
-	// When the operation type is known to this package, this field contains
+	// When the operation type is known to this package or registered
+	// using RegisterOperation, this field contains
 	// the operation data object associated with the given operation type,
 	// e.g. Type is TypeVote -> Data contains *VoteOperation.
 	// Otherwise this field contains raw JSON (type *json.RawMessage).
 	Data() interface{}
 }
 
+// DecodeOperation decodes an operation serialized by the transaction encoder,
+// i.e. the operation code followed by the operation data.
+// The operation code is looked up in the decoder catalog, see transaction.SetCatalog.
+func DecodeOperation(decoder *transaction.Decoder) (Operation, error) {
+	code, err := decoder.DecodeUVarint()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode operation code")
+	}
+	catalog := decoderOpCatalog(decoder)
+	opType, ok := catalog.Type(uint16(code))
+	if !ok || code > math.MaxUint16 {
+		return nil, errors.Errorf("operation code not known to the %v catalog: %v", catalog.Name(), code)
+	}
+
+	op, ok := newDataObject(opType)
+	if !ok {
+		return nil, errors.Errorf("operation not supported: %v", opType)
+	}
+
+	unmarshaller, ok := op.(transaction.TransactionUnmarshaller)
+	if !ok {
+		return nil, errors.Errorf("operation not supported: %v", opType)
+	}
+	if err := unmarshaller.UnmarshalTransaction(decoder); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode operation: %v", opType)
+	}
+	return op, nil
+}
+
 type Operations []Operation
 
 func (ops *Operations) UnmarshalJSON(data []byte) error {
@@ -113,12 +212,25 @@ func (ops *Operations) UnmarshalJSON(data []byte) error {
     return json.Marshal(tuples)
 }*/
 
+// MarshalJSON implements json.Marshaler.
+// Known operations are their own data objects and *UnknownOperation
+// marshals into the raw body, so the operation itself is used as the data.
+//
+// The output is not HTML-escaped, but json.Marshal escapes the output of every
+// json.Marshaler it calls, so when the operations are marshalled as a part
+// of a Transaction or a Block using json.Marshal, <, > and & in strings
+// and raw bodies turn into \u003c, \u003e and \u0026. The result is equivalent JSON.
+// Use JSONMarshal to keep the HTML characters.
+//
+// Both json.Marshal and JSONMarshal compact the output of every json.Marshaler,
+// so the whitespace within raw bodies is dropped either way.
+// Use UnknownOperation.RawJSON to get a body byte for byte.
 func (ops Operations) MarshalJSON() ([]byte, error) {
 	tuples := make([]*operationTuple, 0, len(ops))
 	for _, op := range ops {
 		tuples = append(tuples, &operationTuple{
 			Type: op.Type(),
-			Data: op.Data().(Operation),
+			Data: op,
 		})
 	}
 	return JSONMarshal(tuples)
@@ -143,6 +255,9 @@ func (op *operationTuple) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// JSONMarshal works the same way as json.Marshal, but it does not escape HTML
+// characters, not even in the output of nested json.Marshaler implementations.
+// Like json.Encoder, it appends a newline.
 func JSONMarshal(t interface{}) ([]byte, error) {
 	buffer := &bytes.Buffer{}
 	encoder := json.NewEncoder(buffer)
@@ -168,13 +283,8 @@ func (op *operationTuple) UnmarshalJSON(data []byte) error {
 	}
 
 	// Unmarshal the data.
-	var opData Operation
-	template, ok := dataObjects[opType]
+	opData, ok := newDataObject(opType)
 	if ok {
-		opData = reflect.New(
-			reflect.Indirect(reflect.ValueOf(template)).Type(),
-		).Interface().(Operation)
-
 		if err := json.Unmarshal(*raw[1], opData); err != nil {
 			//return errors.Wrapf(err, "failed to unmarshal Operation.Data: %v", string(*raw[1]))
 			opData = &UnknownOperation{opType, raw[1]}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"reflect"
 	"strings"
+	"sync"
 
 	// Vendor
 	"github.com/pkg/errors"
@@ -20,10 +21,43 @@ const (
 	TypeReblog = "reblog"
 )
 
-var customJSONDataObjects = map[string]interface{}{
+// followPluginDataObjects keeps mapping follow plugin operation name -> data object.
+// All the follow plugin operations use the TypeFollow custom_json ID, see UnmarshalData.
+var followPluginDataObjects = map[string]interface{}{
 	TypeFollow: &FollowOperation{},
-	TypeReblog: &FollowOperation{},
-	//TypeReblog: &ReblogOperation{},
+	TypeReblog: &ReblogOperation{},
+}
+
+// customJSONDataObjects keeps mapping custom_json ID -> data object.
+// The map is guarded by customJSONDataObjectsLock, use RegisterCustomJSON to modify it.
+var customJSONDataObjects = map[string]interface{}{}
+
+var customJSONDataObjectsLock sync.RWMutex
+
+// RegisterCustomJSON sets the data object template used by UnmarshalData
+// to unmarshal the JSON of custom_json operations with the given ID.
+// It can be used to plug in app protocols. It is safe for concurrent use.
+//
+// An ID can be registered only once and TypeFollow is reserved for the follow plugin,
+// an error is returned otherwise.
+//
+// The template must be a non-nil pointer, a new instance is created for every operation.
+func RegisterCustomJSON(id string, template interface{}) error {
+	if err := checkTemplate(template); err != nil {
+		return errors.Wrapf(err, "custom_json %v", id)
+	}
+	if id == TypeFollow {
+		return errors.Errorf("custom_json %v: reserved for the follow plugin", id)
+	}
+
+	customJSONDataObjectsLock.Lock()
+	defer customJSONDataObjectsLock.Unlock()
+
+	if _, ok := customJSONDataObjects[id]; ok {
+		return errors.Errorf("custom_json %v: already registered", id)
+	}
+	customJSONDataObjects[id] = template
+	return nil
 }
 
 // FC_REFLECT( steemit::chain::custom_json_operation,
@@ -48,20 +82,20 @@ func (op *CustomJSONOperation) Data() interface{} {
 	return op
 }
 
+// UnmarshalData unmarshals the JSON into a new instance of the data object
+// registered for the operation ID. It returns nil when there is no data object registered.
+//
+// The JSON is either the data object itself or [name, data object],
+// which is what the follow plugin uses. The follow plugin uses the same ID
+// for all its operations, so for the "follow" ID the data object
+// is chosen by the name, e.g. *ReblogOperation for ["reblog", {...}].
 func (op *CustomJSONOperation) UnmarshalData() (interface{}, error) {
-	// Get the corresponding data object template.
-	template, ok := customJSONDataObjects[op.ID]
-	if !ok {
-		// In case there is no corresponding template, return nil.
-		return nil, nil
-	}
-
-	// Clone the template.
-	opData := reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface()
-
 	// Prepare the whole operation tuple.
-	var bodyReader io.Reader
-	if op.JSON[0] == '[' {
+	var (
+		name       = TypeFollow
+		bodyReader io.Reader
+	)
+	if strings.HasPrefix(strings.TrimSpace(op.JSON), "[") {
 		rawTuple := make([]json.RawMessage, 2)
 		if err := json.NewDecoder(strings.NewReader(op.JSON)).Decode(&rawTuple); err != nil {
 			return nil, errors.Wrapf(err,
@@ -76,10 +110,35 @@ func (op *CustomJSONOperation) UnmarshalData() (interface{}, error) {
 			return nil, errors.Errorf("invalid CustomJSONOperation.JSON: \n%v", op.JSON)
 		}
 		bodyReader = bytes.NewReader([]byte(rawTuple[1]))
+
+		var tupleName string
+		if json.Unmarshal(rawTuple[0], &tupleName) == nil && tupleName != "" {
+			name = tupleName
+		}
 	} else {
 		bodyReader = strings.NewReader(op.JSON)
 	}
 
+	// Get the corresponding data object template.
+	var (
+		template interface{}
+		ok       bool
+	)
+	if op.ID == TypeFollow {
+		template, ok = followPluginDataObjects[name]
+	} else {
+		customJSONDataObjectsLock.RLock()
+		template, ok = customJSONDataObjects[op.ID]
+		customJSONDataObjectsLock.RUnlock()
+	}
+	if !ok {
+		// In case there is no corresponding template, return nil.
+		return nil, nil
+	}
+
+	// Clone the template.
+	opData := reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface()
+
 	// Unmarshal into the new object instance.
 	if err := json.NewDecoder(bodyReader).Decode(opData); err != nil {
 		return nil, errors.Wrapf(err,
@@ -91,10 +150,19 @@ func (op *CustomJSONOperation) UnmarshalData() (interface{}, error) {
 
 func (op *CustomJSONOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeCustomJSON.Code()))
+	enc.Encode(TypeCustomJSON)
 	enc.EncodeArrString(op.RequiredAuths)
 	enc.EncodeArrString(op.RequiredPostingAuths)
 	enc.Encode(op.ID)
 	enc.Encode(op.JSON)
 	return enc.Err()
 }
+
+func (op *CustomJSONOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeArrString(&op.RequiredAuths)
+	dec.DecodeArrString(&op.RequiredPostingAuths)
+	dec.Decode(&op.ID)
+	dec.Decode(&op.JSON)
+	return dec.Err()
+}
@@ -1,5 +1,19 @@
 package types
 
+import (
+	// Stdlib
+	"encoding/json"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// Follow kinds as used in FollowOperation.What.
+const (
+	FollowKindBlog   = "blog"
+	FollowKindIgnore = "ignore"
+)
+
 type FollowOperation struct {
 	Follower  string   `json:"follower"`
 	Following string   `json:"following"`
@@ -9,8 +23,102 @@ type FollowOperation struct {
 	Permlink  string   `json:"permlink"`
 }
 
-/*type ReblogOperation struct {
+// ReblogOperation is the body of the reblog custom_json.
+// CustomJSONOperation.UnmarshalData returns it for ["reblog", {...}].
+type ReblogOperation struct {
 	Account  string `json:"account"`
 	Author   string `json:"author"`
 	Permlink string `json:"permlink"`
-}*/
+}
+
+// followBody is the body of the follow custom_json.
+type followBody struct {
+	Follower  string   `json:"follower"`
+	Following string   `json:"following"`
+	What      []string `json:"what"`
+}
+
+// The follow plugin operations are custom_json operations. ScorumOpCatalog
+// contains no custom_json, so the builders below take the catalog the operation
+// is going to be encoded with, e.g. SteemOpCatalog, and fail unless it contains custom_json.
+// ScorumOpCatalog is used when the catalog is nil.
+
+// NewFollowOperation returns the custom_json operation making follower follow the blog of following.
+func NewFollowOperation(catalog *OpCatalog, follower, following string) (*CustomJSONOperation, error) {
+	return newFollowOperation(catalog, follower, following, FollowKindBlog)
+}
+
+// NewUnfollowOperation returns the custom_json operation making follower stop
+// following or muting following.
+func NewUnfollowOperation(catalog *OpCatalog, follower, following string) (*CustomJSONOperation, error) {
+	return newFollowOperation(catalog, follower, following)
+}
+
+// NewMuteOperation returns the custom_json operation making follower mute following.
+func NewMuteOperation(catalog *OpCatalog, follower, following string) (*CustomJSONOperation, error) {
+	return newFollowOperation(catalog, follower, following, FollowKindIgnore)
+}
+
+func newFollowOperation(catalog *OpCatalog, follower, following string, what ...string) (*CustomJSONOperation, error) {
+	if err := ValidateAccountName(follower); err != nil {
+		return nil, errors.Wrap(err, "follower")
+	}
+	if err := ValidateAccountName(following); err != nil {
+		return nil, errors.Wrap(err, "following")
+	}
+	if follower == following {
+		return nil, errors.New("an account cannot follow itself")
+	}
+
+	return newFollowPluginOperation(catalog, follower, TypeFollow, &followBody{
+		Follower:  follower,
+		Following: following,
+		What:      append([]string{}, what...),
+	})
+}
+
+// NewReblogOperation returns the custom_json operation making account reblog the given post.
+func NewReblogOperation(catalog *OpCatalog, account, author, permlink string) (*CustomJSONOperation, error) {
+	if err := ValidateAccountName(account); err != nil {
+		return nil, errors.Wrap(err, "account")
+	}
+	if err := ValidateAccountName(author); err != nil {
+		return nil, errors.Wrap(err, "author")
+	}
+	if account == author {
+		return nil, errors.New("an account cannot reblog its own post")
+	}
+	if permlink == "" {
+		return nil, errors.New("permlink not set")
+	}
+
+	return newFollowPluginOperation(catalog, account, TypeReblog, &ReblogOperation{
+		Account:  account,
+		Author:   author,
+		Permlink: permlink,
+	})
+}
+
+// newFollowPluginOperation returns the custom_json operation processed by the follow plugin,
+// i.e. the ID is "follow" and the JSON is [name, body].
+// The operation is authorized by the posting key of account.
+func newFollowPluginOperation(catalog *OpCatalog, account, name string, body interface{}) (*CustomJSONOperation, error) {
+	if catalog == nil {
+		catalog = ScorumOpCatalog
+	}
+	if _, ok := catalog.Code(TypeCustomJSON); !ok {
+		return nil, errors.Errorf("operation not supported by the %v catalog: %v", catalog.Name(), TypeCustomJSON)
+	}
+
+	data, err := json.Marshal([]interface{}{name, body})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %v", name)
+	}
+
+	return &CustomJSONOperation{
+		RequiredAuths:        []string{},
+		RequiredPostingAuths: []string{account},
+		ID:                   TypeFollow,
+		JSON:                 string(data),
+	}, nil
+}
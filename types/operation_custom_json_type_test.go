@@ -0,0 +1,129 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"reflect"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+func TestFollowPluginOperations(t *testing.T) {
+	tests := []struct {
+		op       func() (*CustomJSONOperation, error)
+		json     string
+		expected interface{}
+	}{
+		{
+			func() (*CustomJSONOperation, error) { return NewFollowOperation(SteemOpCatalog, "alice", "bob") },
+			`["follow",{"follower":"alice","following":"bob","what":["blog"]}]`,
+			&FollowOperation{Follower: "alice", Following: "bob", What: []string{"blog"}},
+		},
+		{
+			func() (*CustomJSONOperation, error) { return NewUnfollowOperation(SteemOpCatalog, "alice", "bob") },
+			`["follow",{"follower":"alice","following":"bob","what":[]}]`,
+			&FollowOperation{Follower: "alice", Following: "bob", What: []string{}},
+		},
+		{
+			func() (*CustomJSONOperation, error) { return NewMuteOperation(SteemOpCatalog, "alice", "bob") },
+			`["follow",{"follower":"alice","following":"bob","what":["ignore"]}]`,
+			&FollowOperation{Follower: "alice", Following: "bob", What: []string{"ignore"}},
+		},
+		{
+			func() (*CustomJSONOperation, error) {
+				return NewReblogOperation(SteemOpCatalog, "alice", "bob", "hello-world")
+			},
+			`["reblog",{"account":"alice","author":"bob","permlink":"hello-world"}]`,
+			&ReblogOperation{Account: "alice", Author: "bob", Permlink: "hello-world"},
+		},
+	}
+
+	for _, test := range tests {
+		op, err := test.op()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if op.ID != "follow" || op.JSON != test.json {
+			t.Errorf("unexpected operation: %+v", op)
+		}
+		if len(op.RequiredAuths) != 0 || !reflect.DeepEqual(op.RequiredPostingAuths, []string{"alice"}) {
+			t.Errorf("unexpected auths: %+v", op)
+		}
+
+		data, err := op.UnmarshalData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(data, test.expected) {
+			t.Errorf("expected %+v, got %+v", test.expected, data)
+		}
+	}
+}
+
+func TestFollowPluginOperations_Invalid(t *testing.T) {
+	for _, f := range []func() (*CustomJSONOperation, error){
+		func() (*CustomJSONOperation, error) { return NewFollowOperation(SteemOpCatalog, "alice", "alice") },
+		func() (*CustomJSONOperation, error) { return NewFollowOperation(SteemOpCatalog, "Alice", "bob") },
+		func() (*CustomJSONOperation, error) { return NewUnfollowOperation(SteemOpCatalog, "alice", "") },
+		func() (*CustomJSONOperation, error) { return NewMuteOperation(SteemOpCatalog, "alice", "b") },
+		func() (*CustomJSONOperation, error) {
+			return NewReblogOperation(SteemOpCatalog, "alice", "alice", "hello")
+		},
+		func() (*CustomJSONOperation, error) { return NewReblogOperation(SteemOpCatalog, "alice", "bob", "") },
+		func() (*CustomJSONOperation, error) { return NewReblogOperation(SteemOpCatalog, "al", "bob", "hello") },
+	} {
+		if op, err := f(); err == nil {
+			t.Errorf("expected an error, got %+v", op)
+		}
+	}
+}
+
+func TestFollowPluginOperations_Catalog(t *testing.T) {
+	// Scorum has no custom_json, the default catalog is rejected up front.
+	for _, catalog := range []*OpCatalog{nil, ScorumOpCatalog} {
+		if op, err := NewFollowOperation(catalog, "alice", "bob"); err == nil {
+			t.Errorf("expected an error, got %+v", op)
+		}
+	}
+
+	op, err := NewFollowOperation(SteemOpCatalog, "alice", "bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b).Encode(op); err == nil {
+		t.Error("expected an error encoding custom_json with the default catalog")
+	}
+
+	testRoundTrip(t, op,
+		"12000105616c69636506666f6c6c6f77415b22666f6c6c6f77222c7b22666f6c6c6f776572223a22616c696365222c22"+
+			"666f6c6c6f77696e67223a22626f62222c2277686174223a5b22626c6f67225d7d5d",
+		transaction.SetCatalog(SteemOpCatalog))
+}
+
+func TestCustomJSONOperation_UnmarshalData_FollowPlugin(t *testing.T) {
+	tests := []struct {
+		json     string
+		expected interface{}
+	}{
+		{`["reblog",{"account":"alice","author":"bob","permlink":"hello"}]`,
+			&ReblogOperation{Account: "alice", Author: "bob", Permlink: "hello"}},
+		{`{"follower":"alice","following":"bob","what":["blog"]}`,
+			&FollowOperation{Follower: "alice", Following: "bob", What: []string{"blog"}}},
+		{`["unknown",{}]`, nil},
+	}
+
+	for _, test := range tests {
+		op := &CustomJSONOperation{ID: TypeFollow, JSON: test.json}
+		data, err := op.UnmarshalData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(data, test.expected) {
+			t.Errorf("%v: expected %+v, got %+v", test.json, test.expected, data)
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package types
+
+import (
+	// Stdlib
+	"encoding/json"
+	"reflect"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// ProposalOpType represents the type of an operation proposed to a committee.
+type ProposalOpType string
+
+const (
+	ProposalRegistrationCommitteeAddMember      ProposalOpType = "registration_committee_add_member"
+	ProposalRegistrationCommitteeExcludeMember  ProposalOpType = "registration_committee_exclude_member"
+	ProposalRegistrationCommitteeChangeQuorum   ProposalOpType = "registration_committee_change_quorum"
+	ProposalDevelopmentCommitteeAddMember       ProposalOpType = "development_committee_add_member"
+	ProposalDevelopmentCommitteeExcludeMember   ProposalOpType = "development_committee_exclude_member"
+	ProposalDevelopmentCommitteeChangeQuorum    ProposalOpType = "development_committee_change_quorum"
+	ProposalDevelopmentCommitteeWithdrawVesting ProposalOpType = "development_committee_withdraw_vesting"
+	ProposalDevelopmentCommitteeTransfer        ProposalOpType = "development_committee_transfer"
+)
+
+// proposalOpTypes lists the proposal operations in the order of the proposal_operation static_variant.
+var proposalOpTypes = []ProposalOpType{
+	ProposalRegistrationCommitteeAddMember,
+	ProposalRegistrationCommitteeExcludeMember,
+	ProposalRegistrationCommitteeChangeQuorum,
+	ProposalDevelopmentCommitteeAddMember,
+	ProposalDevelopmentCommitteeExcludeMember,
+	ProposalDevelopmentCommitteeChangeQuorum,
+	ProposalDevelopmentCommitteeWithdrawVesting,
+	ProposalDevelopmentCommitteeTransfer,
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (kind ProposalOpType) MarshalTransaction(encoder *transaction.Encoder) error {
+	for i, v := range proposalOpTypes {
+		if v == kind {
+			return encoder.EncodeUVarint(uint64(i))
+		}
+	}
+	return errors.Errorf("unknown proposal operation: %v", kind)
+}
+
+// ProposalOperation is an operation executed once the proposal is accepted by the committee.
+type ProposalOperation interface {
+	Type() ProposalOpType
+}
+
+// proposalDataObjects keeps mapping proposal operation type -> operation data object.
+var proposalDataObjects = map[ProposalOpType]ProposalOperation{
+	ProposalRegistrationCommitteeAddMember:      &RegistrationCommitteeAddMemberOperation{},
+	ProposalRegistrationCommitteeExcludeMember:  &RegistrationCommitteeExcludeMemberOperation{},
+	ProposalRegistrationCommitteeChangeQuorum:   &RegistrationCommitteeChangeQuorumOperation{},
+	ProposalDevelopmentCommitteeAddMember:       &DevelopmentCommitteeAddMemberOperation{},
+	ProposalDevelopmentCommitteeExcludeMember:   &DevelopmentCommitteeExcludeMemberOperation{},
+	ProposalDevelopmentCommitteeChangeQuorum:    &DevelopmentCommitteeChangeQuorumOperation{},
+	ProposalDevelopmentCommitteeWithdrawVesting: &DevelopmentCommitteeWithdrawVestingOperation{},
+	ProposalDevelopmentCommitteeTransfer:        &DevelopmentCommitteeTransferOperation{},
+}
+
+// QuorumType selects the quorum changed by a change quorum proposal.
+type QuorumType string
+
+const (
+	QuorumNone          QuorumType = "none_quorum"
+	QuorumAddMember     QuorumType = "add_member_quorum"
+	QuorumExcludeMember QuorumType = "exclude_member_quorum"
+	QuorumBase          QuorumType = "base_quorum"
+	QuorumTransfer      QuorumType = "transfer_quorum"
+)
+
+var quorumTypes = []string{
+	string(QuorumNone),
+	string(QuorumAddMember),
+	string(QuorumExcludeMember),
+	string(QuorumBase),
+	string(QuorumTransfer),
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (kind QuorumType) MarshalTransaction(encoder *transaction.Encoder) error {
+	return encodeEnum(encoder, quorumTypes, string(kind))
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (kind *QuorumType) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	value, err := decodeEnum(decoder, quorumTypes)
+	*kind = QuorumType(value)
+	return err
+}
+
+// FC_REFLECT( scorum::protocol::proposal_create_operation,
+//             (creator)
+//             (lifetime_sec)
+//             (operation) )
+
+// ProposalCreateOperation proposes an operation to the committee the creator is a member of.
+// The operation is executed once enough committee members vote for the proposal.
+type ProposalCreateOperation struct {
+	Creator     string            `json:"creator"`
+	LifetimeSec uint32            `json:"lifetime_sec"`
+	Operation   ProposalOperation `json:"operation"`
+}
+
+func (op *ProposalCreateOperation) Type() OpType {
+	return TypeProposalCreate
+}
+
+func (op *ProposalCreateOperation) Data() interface{} {
+	return op
+}
+
+func (op *ProposalCreateOperation) MarshalJSON() ([]byte, error) {
+	if op.Operation == nil {
+		return nil, errors.New("proposal operation not set")
+	}
+	return JSONMarshal(map[string]interface{}{
+		"creator":      op.Creator,
+		"lifetime_sec": op.LifetimeSec,
+		"operation":    []interface{}{op.Operation.Type(), op.Operation},
+	})
+}
+
+func (op *ProposalCreateOperation) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Creator     string            `json:"creator"`
+		LifetimeSec uint32            `json:"lifetime_sec"`
+		Operation   []json.RawMessage `json:"operation"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal ProposalCreateOperation: %v", string(data))
+	}
+	if len(raw.Operation) != 2 {
+		return errors.Errorf("invalid proposal operation object: %v", string(data))
+	}
+
+	var opType ProposalOpType
+	if err := json.Unmarshal(raw.Operation[0], &opType); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal proposal operation type: %v", string(raw.Operation[0]))
+	}
+	opData, err := newProposalOperation(opType)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(raw.Operation[1], opData); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal proposal operation: %v", string(raw.Operation[1]))
+	}
+
+	op.Creator = raw.Creator
+	op.LifetimeSec = raw.LifetimeSec
+	op.Operation = opData
+	return nil
+}
+
+func (op *ProposalCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if op.Operation == nil {
+		return errors.New("proposal operation not set")
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeProposalCreate)
+	enc.Encode(op.Creator)
+	enc.Encode(op.LifetimeSec)
+	enc.Encode(op.Operation)
+	return enc.Err()
+}
+
+func (op *ProposalCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Creator)
+	dec.Decode(&op.LifetimeSec)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+
+	code, err := decoder.DecodeUVarint()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode proposal operation code")
+	}
+	if code >= uint64(len(proposalOpTypes)) {
+		return errors.Errorf("unknown proposal operation code: %v", code)
+	}
+	opData, err := newProposalOperation(proposalOpTypes[code])
+	if err != nil {
+		return err
+	}
+	if err := decoder.Decode(opData); err != nil {
+		return errors.Wrapf(err, "failed to decode proposal operation: %v", opData.Type())
+	}
+	op.Operation = opData
+	return nil
+}
+
+func newProposalOperation(opType ProposalOpType) (ProposalOperation, error) {
+	template, ok := proposalDataObjects[opType]
+	if !ok {
+		return nil, errors.Errorf("unknown proposal operation: %v", opType)
+	}
+	return reflect.New(reflect.Indirect(reflect.ValueOf(template)).Type()).Interface().(ProposalOperation), nil
+}
+
+// RegistrationCommitteeAddMemberOperation adds a member to the registration committee.
+type RegistrationCommitteeAddMemberOperation struct {
+	AccountName string `json:"account_name"`
+}
+
+func (op *RegistrationCommitteeAddMemberOperation) Type() ProposalOpType {
+	return ProposalRegistrationCommitteeAddMember
+}
+
+func (op *RegistrationCommitteeAddMemberOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalRegistrationCommitteeAddMember)
+	enc.Encode(op.AccountName)
+	return enc.Err()
+}
+
+func (op *RegistrationCommitteeAddMemberOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountName)
+	return dec.Err()
+}
+
+// RegistrationCommitteeExcludeMemberOperation excludes a member from the registration committee.
+type RegistrationCommitteeExcludeMemberOperation struct {
+	AccountName string `json:"account_name"`
+}
+
+func (op *RegistrationCommitteeExcludeMemberOperation) Type() ProposalOpType {
+	return ProposalRegistrationCommitteeExcludeMember
+}
+
+func (op *RegistrationCommitteeExcludeMemberOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalRegistrationCommitteeExcludeMember)
+	enc.Encode(op.AccountName)
+	return enc.Err()
+}
+
+func (op *RegistrationCommitteeExcludeMemberOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountName)
+	return dec.Err()
+}
+
+// RegistrationCommitteeChangeQuorumOperation changes a quorum of the registration committee.
+type RegistrationCommitteeChangeQuorumOperation struct {
+	Quorum          uint16     `json:"quorum"`
+	CommitteeQuorum QuorumType `json:"committee_quorum"`
+}
+
+func (op *RegistrationCommitteeChangeQuorumOperation) Type() ProposalOpType {
+	return ProposalRegistrationCommitteeChangeQuorum
+}
+
+func (op *RegistrationCommitteeChangeQuorumOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalRegistrationCommitteeChangeQuorum)
+	enc.Encode(op.Quorum)
+	enc.Encode(op.CommitteeQuorum)
+	return enc.Err()
+}
+
+func (op *RegistrationCommitteeChangeQuorumOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Quorum)
+	dec.Decode(&op.CommitteeQuorum)
+	return dec.Err()
+}
+
+// DevelopmentCommitteeAddMemberOperation adds a member to the development committee.
+type DevelopmentCommitteeAddMemberOperation struct {
+	AccountName string `json:"account_name"`
+}
+
+func (op *DevelopmentCommitteeAddMemberOperation) Type() ProposalOpType {
+	return ProposalDevelopmentCommitteeAddMember
+}
+
+func (op *DevelopmentCommitteeAddMemberOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalDevelopmentCommitteeAddMember)
+	enc.Encode(op.AccountName)
+	return enc.Err()
+}
+
+func (op *DevelopmentCommitteeAddMemberOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountName)
+	return dec.Err()
+}
+
+// DevelopmentCommitteeExcludeMemberOperation excludes a member from the development committee.
+type DevelopmentCommitteeExcludeMemberOperation struct {
+	AccountName string `json:"account_name"`
+}
+
+func (op *DevelopmentCommitteeExcludeMemberOperation) Type() ProposalOpType {
+	return ProposalDevelopmentCommitteeExcludeMember
+}
+
+func (op *DevelopmentCommitteeExcludeMemberOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalDevelopmentCommitteeExcludeMember)
+	enc.Encode(op.AccountName)
+	return enc.Err()
+}
+
+func (op *DevelopmentCommitteeExcludeMemberOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountName)
+	return dec.Err()
+}
+
+// DevelopmentCommitteeChangeQuorumOperation changes a quorum of the development committee.
+type DevelopmentCommitteeChangeQuorumOperation struct {
+	Quorum          uint16     `json:"quorum"`
+	CommitteeQuorum QuorumType `json:"committee_quorum"`
+}
+
+func (op *DevelopmentCommitteeChangeQuorumOperation) Type() ProposalOpType {
+	return ProposalDevelopmentCommitteeChangeQuorum
+}
+
+func (op *DevelopmentCommitteeChangeQuorumOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalDevelopmentCommitteeChangeQuorum)
+	enc.Encode(op.Quorum)
+	enc.Encode(op.CommitteeQuorum)
+	return enc.Err()
+}
+
+func (op *DevelopmentCommitteeChangeQuorumOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Quorum)
+	dec.Decode(&op.CommitteeQuorum)
+	return dec.Err()
+}
+
+// DevelopmentCommitteeWithdrawVestingOperation withdraws SP of the development pool.
+type DevelopmentCommitteeWithdrawVestingOperation struct {
+	VestingShares *Asset `json:"vesting_shares"`
+}
+
+func (op *DevelopmentCommitteeWithdrawVestingOperation) Type() ProposalOpType {
+	return ProposalDevelopmentCommitteeWithdrawVesting
+}
+
+func (op *DevelopmentCommitteeWithdrawVestingOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalDevelopmentCommitteeWithdrawVesting)
+	enc.Encode(op.VestingShares)
+	return enc.Err()
+}
+
+func (op *DevelopmentCommitteeWithdrawVestingOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op.VestingShares = &Asset{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(op.VestingShares)
+	return dec.Err()
+}
+
+// DevelopmentCommitteeTransferOperation transfers SCR from the development pool to an account.
+type DevelopmentCommitteeTransferOperation struct {
+	ToAccount string `json:"to_account"`
+	Amount    *Asset `json:"amount"`
+}
+
+func (op *DevelopmentCommitteeTransferOperation) Type() ProposalOpType {
+	return ProposalDevelopmentCommitteeTransfer
+}
+
+func (op *DevelopmentCommitteeTransferOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(ProposalDevelopmentCommitteeTransfer)
+	enc.Encode(op.ToAccount)
+	enc.Encode(op.Amount)
+	return enc.Err()
+}
+
+func (op *DevelopmentCommitteeTransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op.Amount = &Asset{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.ToAccount)
+	dec.Decode(op.Amount)
+	return dec.Err()
+}
@@ -0,0 +1,221 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+const typeTestOperation OpType = "test_operation"
+
+type testOperation struct {
+	Value string `json:"value"`
+}
+
+func (op *testOperation) Type() OpType {
+	return typeTestOperation
+}
+
+func (op *testOperation) Data() interface{} {
+	return op
+}
+
+func (op *testOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(op.Type())
+	enc.Encode(op.Value)
+	return enc.Err()
+}
+
+func (op *testOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	return decoder.Decode(&op.Value)
+}
+
+// namedOperation is an operation of any type, the type is set by the template.
+type namedOperation struct {
+	kind  OpType
+	Value int `json:"value"`
+}
+
+func (op *namedOperation) Type() OpType {
+	return op.kind
+}
+
+func (op *namedOperation) Data() interface{} {
+	return op
+}
+
+type testCustomJSON struct {
+	Action string `json:"action"`
+	Count  int    `json:"count"`
+}
+
+func TestRegisterOperation(t *testing.T) {
+	defer func() {
+		dataObjectsLock.Lock()
+		delete(dataObjects, typeTestOperation)
+		dataObjectsLock.Unlock()
+	}()
+
+	input := []byte(`[["test_operation",{"value":"hello"}]]`)
+
+	var ops Operations
+	if err := json.Unmarshal(input, &ops); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ops[0].(*UnknownOperation); !ok {
+		t.Fatalf("expected *UnknownOperation, got %T", ops[0])
+	}
+
+	if err := RegisterOperation(typeTestOperation, &testOperation{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(input, &ops); err != nil {
+		t.Fatal(err)
+	}
+	if op, ok := ops[0].(*testOperation); !ok || op.Value != "hello" {
+		t.Errorf("unexpected operation: %#v", ops[0])
+	}
+
+	var nilOperation *testOperation
+	for _, template := range []Operation{nil, nilOperation, &VoteOperation{}} {
+		if err := RegisterOperation(typeTestOperation, template); err == nil {
+			t.Errorf("%#v: expected an error", template)
+		}
+	}
+}
+
+func TestRegisterOperation_Binary(t *testing.T) {
+	defer func() {
+		dataObjectsLock.Lock()
+		delete(dataObjects, typeTestOperation)
+		dataObjectsLock.Unlock()
+	}()
+	if err := RegisterOperation(typeTestOperation, &testOperation{}); err != nil {
+		t.Fatal(err)
+	}
+
+	op := &testOperation{Value: "hello"}
+	if err := transaction.NewEncoder(&bytes.Buffer{}).Encode(op); err == nil {
+		t.Error("expected an error for an operation missing in the catalog")
+	}
+
+	catalog, err := ScorumOpCatalog.Extend("scorum-test", typeTestOperation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code, ok := catalog.Code(typeTestOperation); !ok || int(code) != len(scorumOpTypes) {
+		t.Errorf("unexpected operation code: %v", code)
+	}
+	if _, ok := ScorumOpCatalog.Code(typeTestOperation); ok {
+		t.Error("the original catalog must not be modified")
+	}
+	testRoundTrip(t, op, fmt.Sprintf("%02x", len(scorumOpTypes))+"0568656c6c6f", transaction.SetCatalog(catalog))
+
+	for _, opTypes := range [][]OpType{{TypeVote}, {typeTestOperation, typeTestOperation}} {
+		if _, err := ScorumOpCatalog.Extend("invalid", opTypes...); err == nil {
+			t.Errorf("%v: expected an error", opTypes)
+		}
+	}
+}
+
+func TestRegisterOperation_Parallel(t *testing.T) {
+	const n = 8
+	kinds := make([]OpType, n)
+	for i := range kinds {
+		kinds[i] = OpType(fmt.Sprintf("test_parallel_%v", i))
+	}
+	defer func() {
+		dataObjectsLock.Lock()
+		for _, kind := range kinds {
+			delete(dataObjects, kind)
+		}
+		dataObjectsLock.Unlock()
+	}()
+
+	var wg sync.WaitGroup
+	for i, kind := range kinds {
+		wg.Add(2)
+		go func(kind OpType) {
+			defer wg.Done()
+			if err := RegisterOperation(kind, &namedOperation{kind: kind}); err != nil {
+				t.Error(err)
+			}
+		}(kind)
+		go func(i int, kind OpType) {
+			defer wg.Done()
+			var ops Operations
+			input := fmt.Sprintf(`[["%v",{"value":%v}]]`, kind, i)
+			if err := json.Unmarshal([]byte(input), &ops); err != nil {
+				t.Error(err)
+			}
+		}(i, kind)
+	}
+	wg.Wait()
+
+	for i, kind := range kinds {
+		var ops Operations
+		input := fmt.Sprintf(`[["%v",{"value":%v}]]`, kind, i)
+		if err := json.Unmarshal([]byte(input), &ops); err != nil {
+			t.Fatal(err)
+		}
+		if op, ok := ops[0].(*namedOperation); !ok || op.Value != i {
+			t.Errorf("%v: unexpected operation: %#v", kind, ops[0])
+		}
+	}
+}
+
+func TestRegisterCustomJSON(t *testing.T) {
+	defer func() {
+		customJSONDataObjectsLock.Lock()
+		delete(customJSONDataObjects, "test")
+		customJSONDataObjectsLock.Unlock()
+	}()
+
+	op := &CustomJSONOperation{ID: "test", JSON: `{"action":"play","count":2}`}
+	if data, err := op.UnmarshalData(); err != nil || data != nil {
+		t.Fatalf("expected no data, got %v, %v", data, err)
+	}
+
+	if err := RegisterCustomJSON("test", &testCustomJSON{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{
+		`{"action":"play","count":2}`,
+		`["play",{"action":"play","count":2}]`,
+	} {
+		op.JSON = input
+		data, err := op.UnmarshalData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := &testCustomJSON{Action: "play", Count: 2}
+		if !reflect.DeepEqual(data, expected) {
+			t.Errorf("%v: expected %+v, got %+v", input, expected, data)
+		}
+	}
+
+	if err := RegisterCustomJSON("other", testCustomJSON{}); err == nil {
+		t.Error("expected an error for a non-pointer template")
+	}
+	if err := RegisterCustomJSON("test", &testCustomJSON{}); err == nil {
+		t.Error("expected an error for an ID registered already")
+	}
+	if err := RegisterCustomJSON(TypeFollow, &testCustomJSON{}); err == nil {
+		t.Error("expected an error for the follow plugin ID")
+	}
+
+	// The follow plugin names are not custom_json IDs.
+	op = &CustomJSONOperation{ID: TypeReblog, JSON: `{"account":"alice"}`}
+	if data, err := op.UnmarshalData(); err != nil || data != nil {
+		t.Errorf("expected no data for the reblog ID, got %v, %v", data, err)
+	}
+}
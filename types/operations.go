@@ -2,8 +2,11 @@ package types
 
 import (
 	// Stdlib
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
 
 	// RPC
@@ -18,6 +21,8 @@ import (
 //             (first_block)
 //             (second_block) )
 
+// ReportOverProductionOperation is not broadcastable any more,
+// so it does not implement MarshalTransaction.
 type ReportOverProductionOperation struct {
 	Reporter string `json:"reporter"`
 }
@@ -38,7 +43,7 @@ func (op *ReportOverProductionOperation) Data() interface{} {
 type ConvertOperation struct {
 	Owner     string `json:"owner"`
 	RequestID uint32 `json:"requestid"`
-	Amount    string `json:"amount"`
+	Amount    *Asset `json:"amount"`
 }
 
 func (op *ConvertOperation) Type() OpType {
@@ -49,16 +54,31 @@ func (op *ConvertOperation) Data() interface{} {
 	return op
 }
 
+func (op *ConvertOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeConvert)
+	enc.Encode(op.Owner)
+	enc.Encode(op.RequestID)
+	enc.Encode(op.Amount)
+	return enc.Err()
+}
+
+func (op *ConvertOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.RequestID)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::feed_publish_operation,
 //             (publisher)
 //             (exchange_rate) )
 
 type FeedPublishOperation struct {
 	Publisher    string `json:"publisher"`
-	ExchangeRate struct {
-		Base  string `json:"base"`
-		Quote string `json:"quote"`
-	} `json:"exchange_rate"`
+	ExchangeRate *Price `json:"exchange_rate"`
 }
 
 func (op *FeedPublishOperation) Type() OpType {
@@ -69,6 +89,22 @@ func (op *FeedPublishOperation) Data() interface{} {
 	return op
 }
 
+func (op *FeedPublishOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeFeedPublish)
+	enc.Encode(op.Publisher)
+	enc.Encode(op.ExchangeRate)
+	return enc.Err()
+}
+
+func (op *FeedPublishOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Publisher)
+	op.ExchangeRate = &Price{}
+	dec.Decode(op.ExchangeRate)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::pow,
 //             (worker)
 //             (input)
@@ -86,13 +122,45 @@ type POW struct {
 //             (account_creation_fee)
 //             (maximum_block_size)
 //             (sbd_interest_rate) );
+//
+// FC_REFLECT( scorum::protocol::chain_properties,
+//             (account_creation_fee)
+//             (maximum_block_size) )
 
+// ChainProperties are the chain properties proposed by a witness.
+// SBDInterestRate is only serialized for SteemOpCatalog, Scorum has no SBD.
 type ChainProperties struct {
-	AccountCreationFee string `json:"account_creation_fee"`
+	AccountCreationFee *Asset `json:"account_creation_fee"`
 	MaximumBlockSize   uint32 `json:"maximum_block_size"`
 	SBDInterestRate    uint16 `json:"sbd_interest_rate"`
 }
 
+func (props *ChainProperties) MarshalTransaction(encoder *transaction.Encoder) error {
+	if props == nil {
+		return errors.New("chain properties not set")
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(props.AccountCreationFee)
+	enc.Encode(props.MaximumBlockSize)
+	if encoderOpCatalog(encoder).layout == steemLayout {
+		enc.Encode(props.SBDInterestRate)
+	}
+	return enc.Err()
+}
+
+func (props *ChainProperties) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	props.AccountCreationFee = &Asset{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(props.AccountCreationFee)
+	dec.Decode(&props.MaximumBlockSize)
+	if decoderOpCatalog(decoder).layout == steemLayout {
+		dec.Decode(&props.SBDInterestRate)
+	}
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::pow_operation,
 //             (worker_account)
 //             (block_id)
@@ -100,6 +168,8 @@ type ChainProperties struct {
 //             (work)
 //             (props) )
 
+// POWOperation is not broadcastable any more, mining has been disabled,
+// so it does not implement MarshalTransaction.
 type POWOperation struct {
 	WorkerAccount string           `json:"worker_account"`
 	BlockID       string           `json:"block_id"`
@@ -127,13 +197,13 @@ func (op *POWOperation) Data() interface{} {
 //             (json_metadata) )
 
 type AccountCreateOperation struct {
-	Fee            string     `json:"fee"`
+	Fee            *Asset     `json:"fee"`
 	Creator        string     `json:"creator"`
 	NewAccountName string     `json:"new_account_name"`
 	Owner          *Authority `json:"owner"`
 	Active         *Authority `json:"active"`
 	Posting        *Authority `json:"posting"`
-	MemoKey        string     `json:"memo_key"`
+	MemoKey        *PublicKey `json:"memo_key"`
 	JsonMetadata   string     `json:"json_metadata"`
 }
 
@@ -145,6 +215,38 @@ func (op *AccountCreateOperation) Data() interface{} {
 	return op
 }
 
+func (op *AccountCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAccountCreate)
+	enc.Encode(op.Fee)
+	enc.Encode(op.Creator)
+	enc.Encode(op.NewAccountName)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Active)
+	enc.Encode(op.Posting)
+	enc.Encode(op.MemoKey)
+	enc.Encode(op.JsonMetadata)
+	return enc.Err()
+}
+
+func (op *AccountCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	op.Fee = &Asset{}
+	dec.Decode(op.Fee)
+	dec.Decode(&op.Creator)
+	dec.Decode(&op.NewAccountName)
+	op.Owner = &Authority{}
+	dec.Decode(op.Owner)
+	op.Active = &Authority{}
+	dec.Decode(op.Active)
+	op.Posting = &Authority{}
+	dec.Decode(op.Posting)
+	op.MemoKey = &PublicKey{}
+	dec.Decode(op.MemoKey)
+	dec.Decode(&op.JsonMetadata)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::account_update_operation,
 //             (account)
 //             (owner)
@@ -158,7 +260,7 @@ type AccountUpdateOperation struct {
 	Owner        *Authority `json:"owner"`
 	Active       *Authority `json:"active"`
 	Posting      *Authority `json:"posting"`
-	MemoKey      string     `json:"memo_key"`
+	MemoKey      *PublicKey `json:"memo_key"`
 	JsonMetadata string     `json:"json_metadata"`
 }
 
@@ -170,6 +272,30 @@ func (op *AccountUpdateOperation) Data() interface{} {
 	return op
 }
 
+func (op *AccountUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAccountUpdate)
+	enc.Encode(op.Account)
+	encodeOptionalAuthority(enc, op.Owner)
+	encodeOptionalAuthority(enc, op.Active)
+	encodeOptionalAuthority(enc, op.Posting)
+	enc.Encode(op.MemoKey)
+	enc.Encode(op.JsonMetadata)
+	return enc.Err()
+}
+
+func (op *AccountUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	op.Owner = decodeOptionalAuthority(dec)
+	op.Active = decodeOptionalAuthority(dec)
+	op.Posting = decodeOptionalAuthority(dec)
+	op.MemoKey = &PublicKey{}
+	dec.Decode(op.MemoKey)
+	dec.Decode(&op.JsonMetadata)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::transfer_operation,
 //             (from)
 //             (to)
@@ -179,7 +305,7 @@ func (op *AccountUpdateOperation) Data() interface{} {
 type TransferOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
+	Amount *Asset `json:"amount"`
 	Memo   string `json:"memo"`
 }
 
@@ -193,14 +319,24 @@ func (op *TransferOperation) Data() interface{} {
 
 func (op *TransferOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeTransfer.Code()))
+	enc.Encode(TypeTransfer)
 	enc.Encode(op.From)
 	enc.Encode(op.To)
-	enc.EncodeMoney(op.Amount)
+	enc.Encode(op.Amount)
 	enc.Encode(op.Memo)
 	return enc.Err()
 }
 
+func (op *TransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	dec.Decode(&op.Memo)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::transfer_to_vesting_operation,
 //             (from)
 //             (to)
@@ -209,7 +345,7 @@ func (op *TransferOperation) MarshalTransaction(encoder *transaction.Encoder) er
 type TransferToVestingOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
+	Amount *Asset `json:"amount"`
 }
 
 func (op *TransferToVestingOperation) Type() OpType {
@@ -220,13 +356,31 @@ func (op *TransferToVestingOperation) Data() interface{} {
 	return op
 }
 
+func (op *TransferToVestingOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeTransferToVesting)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Amount)
+	return enc.Err()
+}
+
+func (op *TransferToVestingOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::withdraw_vesting_operation,
 //             (account)
 //             (vesting_shares) )
 
 type WithdrawVestingOperation struct {
 	Account       string `json:"account"`
-	VestingShares string `json:"vesting_shares"`
+	VestingShares *Asset `json:"vesting_shares"`
 }
 
 func (op *WithdrawVestingOperation) Type() OpType {
@@ -237,6 +391,22 @@ func (op *WithdrawVestingOperation) Data() interface{} {
 	return op
 }
 
+func (op *WithdrawVestingOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeWithdrawVesting)
+	enc.Encode(op.Account)
+	enc.Encode(op.VestingShares)
+	return enc.Err()
+}
+
+func (op *WithdrawVestingOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	op.VestingShares = &Asset{}
+	dec.Decode(op.VestingShares)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::set_withdraw_vesting_route_operation,
 //             (from_account)
 //             (to_account)
@@ -270,13 +440,21 @@ func (op *AccountWitnessVoteOperation) Data() interface{} {
 
 func (op *AccountWitnessVoteOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeAccountWitnessVote.Code()))
+	enc.Encode(TypeAccountWitnessVote)
 	enc.Encode(op.Account)
 	enc.Encode(op.Witness)
 	enc.EncodeBool(op.Approve)
 	return enc.Err()
 }
 
+func (op *AccountWitnessVoteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	dec.Decode(&op.Witness)
+	dec.Decode(&op.Approve)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::account_witness_proxy_operation,
 //             (account)
 //             (proxy) )
@@ -294,6 +472,21 @@ func (op *AccountWitnessProxyOperation) Data() interface{} {
 	return op
 }
 
+func (op *AccountWitnessProxyOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAccountWitnessProxy)
+	enc.Encode(op.Account)
+	enc.Encode(op.Proxy)
+	return enc.Err()
+}
+
+func (op *AccountWitnessProxyOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	dec.Decode(&op.Proxy)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::comment_operation,
 //             (parent_author)
 //             (parent_permlink)
@@ -344,7 +537,7 @@ func (op *CommentOperation) GetMetaData() (resp *CommentOperationJsonMetadata, e
 
 func (op *CommentOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeComment.Code()))
+	enc.Encode(TypeComment)
 	if !op.IsStoryOperation() {
 		enc.Encode(op.ParentAuthor)
 	} else {
@@ -359,6 +552,18 @@ func (op *CommentOperation) MarshalTransaction(encoder *transaction.Encoder) err
 	return enc.Err()
 }
 
+func (op *CommentOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.ParentAuthor)
+	dec.Decode(&op.ParentPermlink)
+	dec.Decode(&op.Author)
+	dec.Decode(&op.Permlink)
+	dec.Decode(&op.Title)
+	dec.Decode(&op.Body)
+	dec.Decode(&op.JsonMetadata)
+	return dec.Err()
+}
+
 type CommentOperationJsonMetadata struct {
 	Tags   []string
 	Image  []string
@@ -389,7 +594,7 @@ func (op *VoteOperation) Data() interface{} {
 
 func (op *VoteOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeVote.Code()))
+	enc.Encode(TypeVote)
 	enc.Encode(op.Voter)
 	enc.Encode(op.Author)
 	enc.Encode(op.Permlink)
@@ -397,6 +602,15 @@ func (op *VoteOperation) MarshalTransaction(encoder *transaction.Encoder) error
 	return enc.Err()
 }
 
+func (op *VoteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Voter)
+	dec.Decode(&op.Author)
+	dec.Decode(&op.Permlink)
+	dec.Decode(&op.Weight)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::custom_operation,
 //             (required_auths)
 //             (id)
@@ -413,8 +627,8 @@ func (op *VoteOperation) MarshalTransaction(encoder *transaction.Encoder) error
 type LimitOrderCreateOperation struct {
 	Owner        string `json:"owner"`
 	OrderID      uint32 `json:"orderid"`
-	AmountToSell string `json:"amount_to_sell"`
-	MinToReceive string `json:"min_to_receive"`
+	AmountToSell *Asset `json:"amount_to_sell"`
+	MinToReceive *Asset `json:"min_to_receive"`
 	FillOrKill   bool   `json:"fill_or_kill"`
 	Expiration   *Time  `json:"expiration"`
 }
@@ -427,6 +641,32 @@ func (op *LimitOrderCreateOperation) Data() interface{} {
 	return op
 }
 
+func (op *LimitOrderCreateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeLimitOrderCreate)
+	enc.Encode(op.Owner)
+	enc.Encode(op.OrderID)
+	enc.Encode(op.AmountToSell)
+	enc.Encode(op.MinToReceive)
+	enc.EncodeBool(op.FillOrKill)
+	enc.Encode(op.Expiration)
+	return enc.Err()
+}
+
+func (op *LimitOrderCreateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.OrderID)
+	op.AmountToSell = &Asset{}
+	dec.Decode(op.AmountToSell)
+	op.MinToReceive = &Asset{}
+	dec.Decode(op.MinToReceive)
+	dec.Decode(&op.FillOrKill)
+	op.Expiration = &Time{}
+	dec.Decode(op.Expiration)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::limit_order_cancel_operation,
 //             (owner)
 //             (orderid) )
@@ -444,6 +684,21 @@ func (op *LimitOrderCancelOperation) Data() interface{} {
 	return op
 }
 
+func (op *LimitOrderCancelOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeLimitOrderCancel)
+	enc.Encode(op.Owner)
+	enc.Encode(op.OrderID)
+	return enc.Err()
+}
+
+func (op *LimitOrderCancelOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.OrderID)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::delete_comment_operation,
 //             (author)
 //             (permlink) )
@@ -463,12 +718,19 @@ func (op *DeleteCommentOperation) Data() interface{} {
 
 func (op *DeleteCommentOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeDeleteComment.Code()))
+	enc.Encode(TypeDeleteComment)
 	enc.Encode(op.Author)
 	enc.Encode(op.Permlink)
 	return enc.Err()
 }
 
+func (op *DeleteCommentOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Author)
+	dec.Decode(&op.Permlink)
+	return dec.Err()
+}
+
 // FC_REFLECT( steemit::chain::comment_options_operation,
 //             (author)
 //             (permlink)
@@ -477,15 +739,25 @@ func (op *DeleteCommentOperation) MarshalTransaction(encoder *transaction.Encode
 //             (allow_votes)
 //             (allow_curation_rewards)
 //             (extensions) )
+//
+// FC_REFLECT( scorum::protocol::comment_options_operation,
+//             (author)
+//             (permlink)
+//             (max_accepted_payout)
+//             (allow_votes)
+//             (allow_curation_rewards)
+//             (extensions) )
 
+// CommentOptionsOperation sets the payout options of a comment.
+// PercentSteemDollars is only serialized for SteemOpCatalog, Scorum has no SBD.
 type CommentOptionsOperation struct {
-	Author               string        `json:"author"`
-	Permlink             string        `json:"permlink"`
-	MaxAcceptedPayout    string        `json:"max_accepted_payout"`
-	PercentSteemDollars  uint16        `json:"percent_steem_dollars"`
-	AllowVotes           bool          `json:"allow_votes"`
-	AllowCurationRewards bool          `json:"allow_curation_rewards"`
-	Extensions           []interface{} `json:"extensions"`
+	Author               string                   `json:"author"`
+	Permlink             string                   `json:"permlink"`
+	MaxAcceptedPayout    *Asset                   `json:"max_accepted_payout"`
+	PercentSteemDollars  uint16                   `json:"percent_steem_dollars"`
+	AllowVotes           bool                     `json:"allow_votes"`
+	AllowCurationRewards bool                     `json:"allow_curation_rewards"`
+	Extensions           CommentOptionsExtensions `json:"extensions"`
 }
 
 func (op *CommentOptionsOperation) Type() OpType {
@@ -498,21 +770,185 @@ func (op *CommentOptionsOperation) Data() interface{} {
 
 func (op *CommentOptionsOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeCommentOptions.Code()))
+	enc.Encode(TypeCommentOptions)
 	enc.Encode(op.Author)
 	enc.Encode(op.Permlink)
-	enc.EncodeMoney(op.MaxAcceptedPayout)
-	enc.Encode(op.PercentSteemDollars)
+	enc.Encode(op.MaxAcceptedPayout)
+	if encoderOpCatalog(encoder).layout == steemLayout {
+		enc.Encode(op.PercentSteemDollars)
+	}
 	enc.EncodeBool(op.AllowVotes)
 	enc.EncodeBool(op.AllowCurationRewards)
-	enc.Encode(byte(0))
+	enc.Encode(op.Extensions)
 	return enc.Err()
 }
 
+func (op *CommentOptionsOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Author)
+	dec.Decode(&op.Permlink)
+	op.MaxAcceptedPayout = &Asset{}
+	dec.Decode(op.MaxAcceptedPayout)
+	if decoderOpCatalog(decoder).layout == steemLayout {
+		dec.Decode(&op.PercentSteemDollars)
+	}
+	dec.Decode(&op.AllowVotes)
+	dec.Decode(&op.AllowCurationRewards)
+	dec.Decode(&op.Extensions)
+	return dec.Err()
+}
+
 type Authority struct {
-	AccountAuths    []*Auth `json:"account_auths"`
-	KeyAuths        []*Auth `json:"key_auths"`
-	WeightThreshold uint32  `json:"weight_threshold"`
+	AccountAuths    []*Auth    `json:"account_auths"`
+	KeyAuths        []*KeyAuth `json:"key_auths"`
+	WeightThreshold uint32     `json:"weight_threshold"`
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+//
+// The node keeps the auths in sorted maps and it computes the signature digest
+// from the sorted form, so the auths are sorted here as well,
+// account auths by name and key auths by the binary key.
+func (auth *Authority) MarshalTransaction(encoder *transaction.Encoder) error {
+	if auth == nil {
+		return errors.New("authority not set")
+	}
+
+	accountAuths := append([]*Auth(nil), auth.AccountAuths...)
+	sort.SliceStable(accountAuths, func(i, j int) bool {
+		return accountAuths[i].Key < accountAuths[j].Key
+	})
+
+	keyAuths := append([]*KeyAuth(nil), auth.KeyAuths...)
+	for _, keyAuth := range keyAuths {
+		if keyAuth.Key == nil {
+			return errors.New("authority: key not set")
+		}
+	}
+	sort.SliceStable(keyAuths, func(i, j int) bool {
+		return bytes.Compare(keyAuths[i].Key.Bytes(), keyAuths[j].Key.Bytes()) < 0
+	})
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(auth.WeightThreshold)
+	enc.EncodeUVarint(uint64(len(accountAuths)))
+	for _, accountAuth := range accountAuths {
+		weight, err := authWeight(accountAuth.Check)
+		if err != nil {
+			return err
+		}
+		enc.Encode(accountAuth.Key)
+		enc.Encode(weight)
+	}
+	enc.EncodeUVarint(uint64(len(keyAuths)))
+	for _, keyAuth := range keyAuths {
+		weight, err := authWeight(keyAuth.Check)
+		if err != nil {
+			return err
+		}
+		enc.Encode(keyAuth.Key)
+		enc.Encode(weight)
+	}
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (auth *Authority) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&auth.WeightThreshold)
+
+	var length uint64
+	dec.DecodeUVarint(&length)
+	auth.AccountAuths = nil
+	for i := uint64(0); i < length && dec.Err() == nil; i++ {
+		var weight uint16
+		accountAuth := &Auth{}
+		dec.Decode(&accountAuth.Key)
+		dec.Decode(&weight)
+		accountAuth.Check = uint32(weight)
+		auth.AccountAuths = append(auth.AccountAuths, accountAuth)
+	}
+
+	dec.DecodeUVarint(&length)
+	auth.KeyAuths = nil
+	for i := uint64(0); i < length && dec.Err() == nil; i++ {
+		var weight uint16
+		keyAuth := &KeyAuth{Key: &PublicKey{}}
+		dec.Decode(keyAuth.Key)
+		dec.Decode(&weight)
+		keyAuth.Check = uint32(weight)
+		auth.KeyAuths = append(auth.KeyAuths, keyAuth)
+	}
+	return dec.Err()
+}
+
+// authWeight converts the weight into weight_type, which is uint16.
+func authWeight(check uint32) (uint16, error) {
+	if check > math.MaxUint16 {
+		return 0, errors.Errorf("authority: weight out of range: %v", check)
+	}
+	return uint16(check), nil
+}
+
+// encodeOptionalAuthority encodes optional<authority>,
+// i.e. a presence flag followed by the authority when it is set.
+func encodeOptionalAuthority(enc *transaction.RollingEncoder, auth *Authority) {
+	if auth == nil {
+		enc.EncodeBool(false)
+		return
+	}
+	enc.EncodeBool(true)
+	enc.Encode(auth)
+}
+
+// decodeOptionalAuthority decodes optional<authority>, nil is returned when not set.
+func decodeOptionalAuthority(dec *transaction.RollingDecoder) *Authority {
+	var present bool
+	dec.DecodeBool(&present)
+	if !present {
+		return nil
+	}
+	auth := &Authority{}
+	dec.Decode(auth)
+	return auth
+}
+
+// decodeAuthorities decodes vector<authority>.
+func decodeAuthorities(dec *transaction.RollingDecoder) []*Authority {
+	var (
+		length uint64
+		auths  []*Authority
+	)
+	dec.DecodeUVarint(&length)
+	for i := uint64(0); i < length && dec.Err() == nil; i++ {
+		auth := &Authority{}
+		dec.Decode(auth)
+		auths = append(auths, auth)
+	}
+	return auths
+}
+
+// checkExtensions makes sure there are no extensions to encode,
+// extensions are not supported in the binary form yet.
+func checkExtensions(extensions []interface{}) error {
+	if len(extensions) != 0 {
+		return errors.New("extensions not supported")
+	}
+	return nil
+}
+
+// decodeNoExtensions is the decoding counterpart of checkExtensions,
+// it fails unless the encoded extensions are empty.
+func decodeNoExtensions(dec *transaction.RollingDecoder) error {
+	var length uint64
+	dec.DecodeUVarint(&length)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+	if length != 0 {
+		return errors.New("extensions not supported")
+	}
+	return nil
 }
 
 // XXX: Not sure about the struct field names.
@@ -549,6 +985,50 @@ func (auth *Auth) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (auth *Auth) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{auth.Key, auth.Check})
+}
+
+// KeyAuth is the key counterpart of Auth, the key being a public key.
+type KeyAuth struct {
+	Key   *PublicKey
+	Check uint32
+}
+
+func (auth *KeyAuth) UnmarshalJSON(data []byte) error {
+	// The auth object is [key, check].
+	raw := make([]json.RawMessage, 2)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 2 {
+		return errors.Errorf("invalid auth object: %v", string(data))
+	}
+
+	// Unmarshal Key.
+	var key PublicKey
+	if err := json.Unmarshal(raw[0], &key); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal KeyAuth.Key: %v", string(raw[0]))
+	}
+
+	// Unmarshal Check.
+	var check uint32
+	if err := json.Unmarshal(raw[1], &check); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal KeyAuth.Check: %v", string(raw[1]))
+	}
+
+	// Update fields.
+	auth.Key = &key
+	auth.Check = check
+	return nil
+}
+
+func (auth *KeyAuth) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]interface{}{auth.Key, auth.Check})
+}
+
+// UnknownOperation is an operation that could not be unmarshalled into
+// a known operation object. The operation body is kept as received.
 type UnknownOperation struct {
 	kind OpType
 	data *json.RawMessage
@@ -562,13 +1042,45 @@ func (op *UnknownOperation) Data() interface{} {
 	return op.data
 }
 
-// test
+// RawJSON returns the operation body as received, i.e. element [1] of the operation object.
+func (op *UnknownOperation) RawJSON() json.RawMessage {
+	if op.data == nil {
+		return nil
+	}
+	return append(json.RawMessage(nil), *op.data...)
+}
+
+// MarshalJSON implements json.Marshaler.
+// The operation body is written back as received, but encoding/json
+// compacts it when the operation is marshalled as a part of another value.
+func (op *UnknownOperation) MarshalJSON() ([]byte, error) {
+	if op.data == nil {
+		return []byte("null"), nil
+	}
+	return *op.data, nil
+}
+
+// FC_REFLECT( steemit::chain::witness_update_operation,
+//             (owner)
+//             (url)
+//             (block_signing_key)
+//             (props)
+//             (fee) )
+//
+// FC_REFLECT( scorum::protocol::witness_update_operation,
+//             (owner)
+//             (url)
+//             (block_signing_key)
+//             (props) )
+
+// WitnessUpdateOperation creates or updates a witness.
+// Fee is only serialized for SteemOpCatalog, Scorum charges no fee.
 type WitnessUpdateOperation struct {
 	Owner           string           `json:"owner"`
 	Url             string           `json:"url"`
-	BlockSigningKey string           `json:"block_signing_key"`
+	BlockSigningKey *PublicKey       `json:"block_signing_key"`
 	Props           *ChainProperties `json:"props"`
-	Fee             string           `json:"fee"`
+	Fee             *Asset           `json:"fee,omitempty"`
 }
 
 func (op *WitnessUpdateOperation) Type() OpType {
@@ -579,6 +1091,34 @@ func (op *WitnessUpdateOperation) Data() interface{} {
 	return op
 }
 
+func (op *WitnessUpdateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeWitnessUpdate)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Url)
+	enc.Encode(op.BlockSigningKey)
+	enc.Encode(op.Props)
+	if encoderOpCatalog(encoder).layout == steemLayout {
+		enc.Encode(op.Fee)
+	}
+	return enc.Err()
+}
+
+func (op *WitnessUpdateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.Url)
+	op.BlockSigningKey = &PublicKey{}
+	dec.Decode(op.BlockSigningKey)
+	op.Props = &ChainProperties{}
+	dec.Decode(op.Props)
+	if decoderOpCatalog(decoder).layout == steemLayout {
+		op.Fee = &Asset{}
+		dec.Decode(op.Fee)
+	}
+	return dec.Err()
+}
+
 type CustomOperation struct {
 	RequiredAuths []string `json:"required_auths"`
 	Id            uint16   `json:"id"`
@@ -593,6 +1133,24 @@ func (op *CustomOperation) Data() interface{} {
 	return op
 }
 
+func (op *CustomOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeCustom)
+	enc.EncodeArrString(op.RequiredAuths)
+	enc.Encode(op.Id)
+	enc.EncodeUVarint(uint64(len(op.Datas)))
+	enc.EncodeRaw(op.Datas)
+	return enc.Err()
+}
+
+func (op *CustomOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeArrString(&op.RequiredAuths)
+	dec.Decode(&op.Id)
+	dec.DecodeBytes(&op.Datas)
+	return dec.Err()
+}
+
 type SetWithdrawVestingRouteOperation struct {
 	FromAccount string `json:"from_account"`
 	ToAccount   string `json:"to_account"`
@@ -608,13 +1166,32 @@ func (op *SetWithdrawVestingRouteOperation) Data() interface{} {
 	return op
 }
 
+func (op *SetWithdrawVestingRouteOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeSetWithdrawVestingRoute)
+	enc.Encode(op.FromAccount)
+	enc.Encode(op.ToAccount)
+	enc.Encode(op.Percent)
+	enc.EncodeBool(op.AutoVest)
+	return enc.Err()
+}
+
+func (op *SetWithdrawVestingRouteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.FromAccount)
+	dec.Decode(&op.ToAccount)
+	dec.Decode(&op.Percent)
+	dec.Decode(&op.AutoVest)
+	return dec.Err()
+}
+
 type LimitOrderCreate2Operation struct {
 	Qwner        string `json:"owner"`
 	Orderid      uint32 `json:"orderid"`
-	AmountToSell string `json:"amount_to_sell"`
-	ExchangeRate string `json:"exchange_rate"`
+	AmountToSell *Asset `json:"amount_to_sell"`
+	ExchangeRate *Price `json:"exchange_rate"`
 	FillOrKill   bool   `json:"fill_or_kill"`
-	Expiration   uint32 `json:"expiration"`
+	Expiration   *Time  `json:"expiration"`
 }
 
 func (op *LimitOrderCreate2Operation) Type() OpType {
@@ -625,6 +1202,32 @@ func (op *LimitOrderCreate2Operation) Data() interface{} {
 	return op
 }
 
+func (op *LimitOrderCreate2Operation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeLimitOrderCreate2)
+	enc.Encode(op.Qwner)
+	enc.Encode(op.Orderid)
+	enc.Encode(op.AmountToSell)
+	enc.Encode(op.ExchangeRate)
+	enc.EncodeBool(op.FillOrKill)
+	enc.Encode(op.Expiration)
+	return enc.Err()
+}
+
+func (op *LimitOrderCreate2Operation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Qwner)
+	dec.Decode(&op.Orderid)
+	op.AmountToSell = &Asset{}
+	dec.Decode(op.AmountToSell)
+	op.ExchangeRate = &Price{}
+	dec.Decode(op.ExchangeRate)
+	dec.Decode(&op.FillOrKill)
+	op.Expiration = &Time{}
+	dec.Decode(op.Expiration)
+	return dec.Err()
+}
+
 type ChallengeAuthorityOperation struct {
 	Challenger   string `json:"challenger"`
 	Challenged   string `json:"challenged"`
@@ -639,6 +1242,23 @@ func (op *ChallengeAuthorityOperation) Data() interface{} {
 	return op
 }
 
+func (op *ChallengeAuthorityOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeChallengeAuthority)
+	enc.Encode(op.Challenger)
+	enc.Encode(op.Challenged)
+	enc.EncodeBool(op.RequireOwner)
+	return enc.Err()
+}
+
+func (op *ChallengeAuthorityOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Challenger)
+	dec.Decode(&op.Challenged)
+	dec.Decode(&op.RequireOwner)
+	return dec.Err()
+}
+
 type ProveAuthorityOperation struct {
 	Challenged   string `json:"challenged"`
 	RequireOwner bool   `json:"require_owner"`
@@ -652,6 +1272,21 @@ func (op *ProveAuthorityOperation) Data() interface{} {
 	return op
 }
 
+func (op *ProveAuthorityOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeProveAuthority)
+	enc.Encode(op.Challenged)
+	enc.EncodeBool(op.RequireOwner)
+	return enc.Err()
+}
+
+func (op *ProveAuthorityOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Challenged)
+	dec.Decode(&op.RequireOwner)
+	return dec.Err()
+}
+
 /*
 {\"weight_threshold\":1,\"account_auths\":[],\"key_auths\":[[\"STM5RrGDY9hCm8UFrbzaUkfA6LnkdRvgupRkfSocHt88Xzh9w8gWg\",1]]}
 */
@@ -670,6 +1305,28 @@ func (op *RequestAccountRecoveryOperation) Data() interface{} {
 	return op
 }
 
+func (op *RequestAccountRecoveryOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if err := checkExtensions(op.Extensions); err != nil {
+		return err
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeRequestAccountRecovery)
+	enc.Encode(op.RecoveryAccount)
+	enc.Encode(op.AccountToRecover)
+	enc.Encode(&op.NewOwnerAuthority)
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *RequestAccountRecoveryOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.RecoveryAccount)
+	dec.Decode(&op.AccountToRecover)
+	dec.Decode(&op.NewOwnerAuthority)
+	return decodeNoExtensions(dec)
+}
+
 type RecoverAccountOperation struct {
 	AccountToRecover     string        `json:"account_to_recover"`
 	NewOwnerAuthority    Authority     `json:"new_owner_authority"`
@@ -685,6 +1342,28 @@ func (op *RecoverAccountOperation) Data() interface{} {
 	return op
 }
 
+func (op *RecoverAccountOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if err := checkExtensions(op.Extensions); err != nil {
+		return err
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeRecoverAccount)
+	enc.Encode(op.AccountToRecover)
+	enc.Encode(&op.NewOwnerAuthority)
+	enc.Encode(&op.RecentOwnerAuthority)
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *RecoverAccountOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountToRecover)
+	dec.Decode(&op.NewOwnerAuthority)
+	dec.Decode(&op.RecentOwnerAuthority)
+	return decodeNoExtensions(dec)
+}
+
 type ChangeRecoveryAccountOperation struct {
 	AccountToRecover   string        `json:"account_to_recover"`
 	NewRecoveryAccount string        `json:"new_recovery_account"`
@@ -699,17 +1378,37 @@ func (op *ChangeRecoveryAccountOperation) Data() interface{} {
 	return op
 }
 
+func (op *ChangeRecoveryAccountOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if err := checkExtensions(op.Extensions); err != nil {
+		return err
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeChangeRecoveryAccount)
+	enc.Encode(op.AccountToRecover)
+	enc.Encode(op.NewRecoveryAccount)
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *ChangeRecoveryAccountOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.AccountToRecover)
+	dec.Decode(&op.NewRecoveryAccount)
+	return decodeNoExtensions(dec)
+}
+
 type EscrowTransferOperation struct {
 	From                 string `json:"from"`
 	To                   string `json:"to"`
-	SbdAmount            string `json:"sbd_amount"`
-	SteemAmount          string `json:"steem_amount"`
+	SbdAmount            *Asset `json:"sbd_amount"`
+	SteemAmount          *Asset `json:"steem_amount"`
 	EscrowId             uint32 `json:"escrow_id"`
 	Agent                string `json:"agent"`
-	Fee                  string `json:"fee"`
+	Fee                  *Asset `json:"fee"`
 	JsonMeta             string `json:"json_meta"`
-	RatificationDeadline string `json:"ratification_deadline"`
-	EscrowExpiration     string `json:"escrow_expiration"`
+	RatificationDeadline *Time  `json:"ratification_deadline"`
+	EscrowExpiration     *Time  `json:"escrow_expiration"`
 }
 
 func (op *EscrowTransferOperation) Type() OpType {
@@ -720,6 +1419,42 @@ func (op *EscrowTransferOperation) Data() interface{} {
 	return op
 }
 
+func (op *EscrowTransferOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeEscrowTransfer)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.SbdAmount)
+	enc.Encode(op.SteemAmount)
+	enc.Encode(op.EscrowId)
+	enc.Encode(op.Agent)
+	enc.Encode(op.Fee)
+	enc.Encode(op.JsonMeta)
+	enc.Encode(op.RatificationDeadline)
+	enc.Encode(op.EscrowExpiration)
+	return enc.Err()
+}
+
+func (op *EscrowTransferOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	op.SbdAmount = &Asset{}
+	dec.Decode(op.SbdAmount)
+	op.SteemAmount = &Asset{}
+	dec.Decode(op.SteemAmount)
+	dec.Decode(&op.EscrowId)
+	dec.Decode(&op.Agent)
+	op.Fee = &Asset{}
+	dec.Decode(op.Fee)
+	dec.Decode(&op.JsonMeta)
+	op.RatificationDeadline = &Time{}
+	dec.Decode(op.RatificationDeadline)
+	op.EscrowExpiration = &Time{}
+	dec.Decode(op.EscrowExpiration)
+	return dec.Err()
+}
+
 type EscrowDisputeOperation struct {
 	From     string `json:"from"`
 	To       string `json:"to"`
@@ -736,6 +1471,27 @@ func (op *EscrowDisputeOperation) Data() interface{} {
 	return op
 }
 
+func (op *EscrowDisputeOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeEscrowDispute)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Agent)
+	enc.Encode(op.Who)
+	enc.Encode(op.EscrowId)
+	return enc.Err()
+}
+
+func (op *EscrowDisputeOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	dec.Decode(&op.Agent)
+	dec.Decode(&op.Who)
+	dec.Decode(&op.EscrowId)
+	return dec.Err()
+}
+
 type EscrowReleaseOperation struct {
 	From        string `json:"from"`
 	To          string `json:"to"`
@@ -743,8 +1499,8 @@ type EscrowReleaseOperation struct {
 	Who         string `json:"who"`
 	Receiver    string `json:"receiver"`
 	EscrowId    uint32 `json:"escrow_id"`
-	SbdAmount   string `json:"sbd_amount"`
-	SteemAmount string `json:"steem_amount"`
+	SbdAmount   *Asset `json:"sbd_amount"`
+	SteemAmount *Asset `json:"steem_amount"`
 }
 
 func (op *EscrowReleaseOperation) Type() OpType {
@@ -755,6 +1511,37 @@ func (op *EscrowReleaseOperation) Data() interface{} {
 	return op
 }
 
+func (op *EscrowReleaseOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeEscrowRelease)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Agent)
+	enc.Encode(op.Who)
+	enc.Encode(op.Receiver)
+	enc.Encode(op.EscrowId)
+	enc.Encode(op.SbdAmount)
+	enc.Encode(op.SteemAmount)
+	return enc.Err()
+}
+
+func (op *EscrowReleaseOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	dec.Decode(&op.Agent)
+	dec.Decode(&op.Who)
+	dec.Decode(&op.Receiver)
+	dec.Decode(&op.EscrowId)
+	op.SbdAmount = &Asset{}
+	dec.Decode(op.SbdAmount)
+	op.SteemAmount = &Asset{}
+	dec.Decode(op.SteemAmount)
+	return dec.Err()
+}
+
+// POW2Operation is not broadcastable any more, mining has been disabled,
+// so it does not implement MarshalTransaction.
 type POW2Operation struct {
 	Input      *POW2Input `json:"input"`
 	PowSummary uint32     `json:"pow_summary"`
@@ -791,10 +1578,33 @@ func (op *EscrowApproveOperation) Data() interface{} {
 	return op
 }
 
+func (op *EscrowApproveOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeEscrowApprove)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Agent)
+	enc.Encode(op.Who)
+	enc.Encode(op.EscrowId)
+	enc.EncodeBool(op.Approve)
+	return enc.Err()
+}
+
+func (op *EscrowApproveOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	dec.Decode(&op.Agent)
+	dec.Decode(&op.Who)
+	dec.Decode(&op.EscrowId)
+	dec.Decode(&op.Approve)
+	return dec.Err()
+}
+
 type TransferToSavingsOperation struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
-	Amount string `json:"amount"`
+	Amount *Asset `json:"amount"`
 	Memo   string `json:"memo"`
 }
 
@@ -808,7 +1618,7 @@ func (op *TransferToSavingsOperation) Data() interface{} {
 
 func (op *TransferToSavingsOperation) MarshalTransaction(encoder *transaction.Encoder) error {
 	enc := transaction.NewRollingEncoder(encoder)
-	enc.EncodeUVarint(uint64(TypeTransferToSavings.Code()))
+	enc.Encode(TypeTransferToSavings)
 	enc.Encode(op.From)
 	enc.Encode(op.To)
 	enc.Encode(op.Amount)
@@ -816,11 +1626,21 @@ func (op *TransferToSavingsOperation) MarshalTransaction(encoder *transaction.En
 	return enc.Err()
 }
 
+func (op *TransferToSavingsOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	dec.Decode(&op.Memo)
+	return dec.Err()
+}
+
 type TransferFromSavingsOperation struct {
 	From      string `json:"from"`
 	RequestId uint32 `json:"request_id"`
 	To        string `json:"to"`
-	Amount    string `json:"amount"`
+	Amount    *Asset `json:"amount"`
 	Memo      string `json:"memo"`
 }
 
@@ -832,6 +1652,28 @@ func (op *TransferFromSavingsOperation) Data() interface{} {
 	return op
 }
 
+func (op *TransferFromSavingsOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeTransferFromSavings)
+	enc.Encode(op.From)
+	enc.Encode(op.RequestId)
+	enc.Encode(op.To)
+	enc.Encode(op.Amount)
+	enc.Encode(op.Memo)
+	return enc.Err()
+}
+
+func (op *TransferFromSavingsOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.RequestId)
+	dec.Decode(&op.To)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	dec.Decode(&op.Memo)
+	return dec.Err()
+}
+
 type CancelTransferFromSavingsOperation struct {
 	From      string `json:"from"`
 	RequestId uint32 `json:"request_id"`
@@ -845,13 +1687,28 @@ func (op *CancelTransferFromSavingsOperation) Data() interface{} {
 	return op
 }
 
+func (op *CancelTransferFromSavingsOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeCancelTransferFromSavings)
+	enc.Encode(op.From)
+	enc.Encode(op.RequestId)
+	return enc.Err()
+}
+
+func (op *CancelTransferFromSavingsOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.RequestId)
+	return dec.Err()
+}
+
 type CustomBinaryOperation struct {
-	RequiredOwnerAuths   []string `json:"required_owner_auths"`
-	RequiredActiveAuths  []string `json:"required_active_auths"`
-	RequiredPostingAuths []string `json:"required_posting_auths"`
-	RequiredAuths        []string `json:"required_auths"`
-	Id                   string   `json:"id"`
-	Datas                []byte   `json:"data"`
+	RequiredOwnerAuths   []string     `json:"required_owner_auths"`
+	RequiredActiveAuths  []string     `json:"required_active_auths"`
+	RequiredPostingAuths []string     `json:"required_posting_auths"`
+	RequiredAuths        []*Authority `json:"required_auths"`
+	Id                   string       `json:"id"`
+	Datas                []byte       `json:"data"`
 }
 
 func (op *CustomBinaryOperation) Type() OpType {
@@ -862,6 +1719,33 @@ func (op *CustomBinaryOperation) Data() interface{} {
 	return op
 }
 
+func (op *CustomBinaryOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeCustomBinary)
+	enc.EncodeArrString(op.RequiredOwnerAuths)
+	enc.EncodeArrString(op.RequiredActiveAuths)
+	enc.EncodeArrString(op.RequiredPostingAuths)
+	enc.EncodeUVarint(uint64(len(op.RequiredAuths)))
+	for _, auth := range op.RequiredAuths {
+		enc.Encode(auth)
+	}
+	enc.Encode(op.Id)
+	enc.EncodeUVarint(uint64(len(op.Datas)))
+	enc.EncodeRaw(op.Datas)
+	return enc.Err()
+}
+
+func (op *CustomBinaryOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.DecodeArrString(&op.RequiredOwnerAuths)
+	dec.DecodeArrString(&op.RequiredActiveAuths)
+	dec.DecodeArrString(&op.RequiredPostingAuths)
+	op.RequiredAuths = decodeAuthorities(dec)
+	dec.Decode(&op.Id)
+	dec.DecodeBytes(&op.Datas)
+	return dec.Err()
+}
+
 type DeclineVotingRightsOperation struct {
 	Account string `json:"account"`
 	Decline bool   `json:"decline"`
@@ -875,10 +1759,25 @@ func (op *DeclineVotingRightsOperation) Data() interface{} {
 	return op
 }
 
+func (op *DeclineVotingRightsOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeDeclineVotingRights)
+	enc.Encode(op.Account)
+	enc.EncodeBool(op.Decline)
+	return enc.Err()
+}
+
+func (op *DeclineVotingRightsOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	dec.Decode(&op.Decline)
+	return dec.Err()
+}
+
 type ResetAccountOperation struct {
-	ResetAccount      string `json:"reset_account"`
-	AccountToReset    string `json:"Account_to_reset"`
-	NewOwnerAuthority string `json:"new_owner_authority"`
+	ResetAccount      string     `json:"reset_account"`
+	AccountToReset    string     `json:"account_to_reset"`
+	NewOwnerAuthority *Authority `json:"new_owner_authority"`
 }
 
 func (op *ResetAccountOperation) Type() OpType {
@@ -889,6 +1788,24 @@ func (op *ResetAccountOperation) Data() interface{} {
 	return op
 }
 
+func (op *ResetAccountOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeResetAccount)
+	enc.Encode(op.ResetAccount)
+	enc.Encode(op.AccountToReset)
+	enc.Encode(op.NewOwnerAuthority)
+	return enc.Err()
+}
+
+func (op *ResetAccountOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.ResetAccount)
+	dec.Decode(&op.AccountToReset)
+	op.NewOwnerAuthority = &Authority{}
+	dec.Decode(op.NewOwnerAuthority)
+	return dec.Err()
+}
+
 type SetResetAccountOperation struct {
 	Account             string `json:"account"`
 	CurrentResetAccount string `json:"current_reset_account"`
@@ -903,11 +1820,28 @@ func (op *SetResetAccountOperation) Data() interface{} {
 	return op
 }
 
+func (op *SetResetAccountOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeSetResetAccount)
+	enc.Encode(op.Account)
+	enc.Encode(op.CurrentResetAccount)
+	enc.Encode(op.ResetAccount)
+	return enc.Err()
+}
+
+func (op *SetResetAccountOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	dec.Decode(&op.CurrentResetAccount)
+	dec.Decode(&op.ResetAccount)
+	return dec.Err()
+}
+
 type ClaimRewardBalanceOperation struct {
 	Account     string `json:"account"`
-	RewardSteem string `json:"reward_steem"`
-	RewardSbd   string `json:"reward_sbd"`
-	RewardVests string `json:"reward_vests"`
+	RewardSteem *Asset `json:"reward_steem"`
+	RewardSbd   *Asset `json:"reward_sbd"`
+	RewardVests *Asset `json:"reward_vests"`
 }
 
 func (op *ClaimRewardBalanceOperation) Type() OpType {
@@ -918,10 +1852,32 @@ func (op *ClaimRewardBalanceOperation) Data() interface{} {
 	return op
 }
 
+func (op *ClaimRewardBalanceOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeClaimRewardBalance)
+	enc.Encode(op.Account)
+	enc.Encode(op.RewardSteem)
+	enc.Encode(op.RewardSbd)
+	enc.Encode(op.RewardVests)
+	return enc.Err()
+}
+
+func (op *ClaimRewardBalanceOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	op.RewardSteem = &Asset{}
+	dec.Decode(op.RewardSteem)
+	op.RewardSbd = &Asset{}
+	dec.Decode(op.RewardSbd)
+	op.RewardVests = &Asset{}
+	dec.Decode(op.RewardVests)
+	return dec.Err()
+}
+
 type DelegateVestingSharesOperation struct {
 	Delegator     string `json:"delegator"`
 	Delegatee     string `json:"delegatee"`
-	VestingShares string `json:"vesting_shares"`
+	VestingShares *Asset `json:"vesting_shares"`
 }
 
 func (op *DelegateVestingSharesOperation) Type() OpType {
@@ -932,15 +1888,33 @@ func (op *DelegateVestingSharesOperation) Data() interface{} {
 	return op
 }
 
+func (op *DelegateVestingSharesOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeDelegateVestingShares)
+	enc.Encode(op.Delegator)
+	enc.Encode(op.Delegatee)
+	enc.Encode(op.VestingShares)
+	return enc.Err()
+}
+
+func (op *DelegateVestingSharesOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Delegator)
+	dec.Decode(&op.Delegatee)
+	op.VestingShares = &Asset{}
+	dec.Decode(op.VestingShares)
+	return dec.Err()
+}
+
 type AccountCreateWithDelegationOperation struct {
-	Fee            string        `json:"fee"`
-	Delegation     string        `json:"delegation"`
+	Fee            *Asset        `json:"fee"`
+	Delegation     *Asset        `json:"delegation"`
 	Creator        string        `json:"creator"`
 	NewAccountName string        `json:"new_account_name"`
 	Owner          *Authority    `json:"owner"`
 	Active         *Authority    `json:"active"`
 	Posting        *Authority    `json:"posting"`
-	MemoKey        string        `json:"memo_key"`
+	MemoKey        *PublicKey    `json:"memo_key"`
 	JsonMetadata   string        `json:"json_metadata"`
 	Extensions     []interface{} `json:"extensions"`
 }
@@ -953,11 +1927,51 @@ func (op *AccountCreateWithDelegationOperation) Data() interface{} {
 	return op
 }
 
+func (op *AccountCreateWithDelegationOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	if err := checkExtensions(op.Extensions); err != nil {
+		return err
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAccountCreateWithDelegation)
+	enc.Encode(op.Fee)
+	enc.Encode(op.Delegation)
+	enc.Encode(op.Creator)
+	enc.Encode(op.NewAccountName)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Active)
+	enc.Encode(op.Posting)
+	enc.Encode(op.MemoKey)
+	enc.Encode(op.JsonMetadata)
+	enc.EncodeUVarint(0)
+	return enc.Err()
+}
+
+func (op *AccountCreateWithDelegationOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	op.Fee = &Asset{}
+	dec.Decode(op.Fee)
+	op.Delegation = &Asset{}
+	dec.Decode(op.Delegation)
+	dec.Decode(&op.Creator)
+	dec.Decode(&op.NewAccountName)
+	op.Owner = &Authority{}
+	dec.Decode(op.Owner)
+	op.Active = &Authority{}
+	dec.Decode(op.Active)
+	op.Posting = &Authority{}
+	dec.Decode(op.Posting)
+	op.MemoKey = &PublicKey{}
+	dec.Decode(op.MemoKey)
+	dec.Decode(&op.JsonMetadata)
+	return decodeNoExtensions(dec)
+}
+
 type FillConvertRequestOperation struct {
 	Owner     string `json:"owner"`
 	Requestid uint32 `json:"requestid"`
-	AmountIn  string `json:"amount_in"`
-	AmountOut string `json:"amount_out"`
+	AmountIn  *Asset `json:"amount_in"`
+	AmountOut *Asset `json:"amount_out"`
 }
 
 func (op *FillConvertRequestOperation) Type() OpType {
@@ -971,9 +1985,9 @@ func (op *FillConvertRequestOperation) Data() interface{} {
 type AuthorRewardOperation struct {
 	Author        string `json:"author"`
 	Permlink      string `json:"permlink"`
-	SbdPayout     string `json:"sbd_payout"`
-	SteemPayout   string `json:"steem_payout"`
-	VestingPayout string `json:"vesting_payout"`
+	SbdPayout     *Asset `json:"sbd_payout"`
+	SteemPayout   *Asset `json:"steem_payout"`
+	VestingPayout *Asset `json:"vesting_payout"`
 }
 
 func (op *AuthorRewardOperation) Type() OpType {
@@ -986,7 +2000,7 @@ func (op *AuthorRewardOperation) Data() interface{} {
 
 type CurationRewardOperation struct {
 	Curator         string `json:"curator"`
-	Reward          string `json:"reward"`
+	Reward          *Asset `json:"reward"`
 	CommentAuthor   string `json:"comment_author"`
 	CommentPermlink string `json:"comment_permlink"`
 }
@@ -1002,7 +2016,7 @@ func (op *CurationRewardOperation) Data() interface{} {
 type CommentRewardOperation struct {
 	Author   string `json:"author"`
 	Permlink string `json:"permlink"`
-	Payout   string `json:"payout"`
+	Payout   *Asset `json:"payout"`
 }
 
 func (op *CommentRewardOperation) Type() OpType {
@@ -1015,7 +2029,7 @@ func (op *CommentRewardOperation) Data() interface{} {
 
 type LiquidityRewardOperation struct {
 	Owner  string `json:"owner"`
-	Payout string `json:"payout"`
+	Payout *Asset `json:"payout"`
 }
 
 func (op *LiquidityRewardOperation) Type() OpType {
@@ -1028,7 +2042,7 @@ func (op *LiquidityRewardOperation) Data() interface{} {
 
 type InterestOperation struct {
 	Owner    string `json:"owner"`
-	Interest string `json:"interest"`
+	Interest *Asset `json:"interest"`
 }
 
 func (op *InterestOperation) Type() OpType {
@@ -1042,8 +2056,8 @@ func (op *InterestOperation) Data() interface{} {
 type FillVestingWithdrawOperation struct {
 	FromAccount string `json:"from_account"`
 	ToAccount   string `json:"to_account"`
-	Withdrawn   string `json:"withdrawn"`
-	Deposited   string `json:"deposited"`
+	Withdrawn   *Asset `json:"withdrawn"`
+	Deposited   *Asset `json:"deposited"`
 }
 
 func (op *FillVestingWithdrawOperation) Type() OpType {
@@ -1057,10 +2071,10 @@ func (op *FillVestingWithdrawOperation) Data() interface{} {
 type FillOrderOperation struct {
 	CurrentOwner   string `json:"current_owner"`
 	CurrentOrderid uint32 `json:"current_orderid"`
-	CurrentPays    string `json:"current_pays"`
+	CurrentPays    *Asset `json:"current_pays"`
 	OpenOwner      string `json:"open_owner"`
 	OpenOrderid    uint32 `json:"open_orderid"`
-	OpenPays       string `json:"open_pays"`
+	OpenPays       *Asset `json:"open_pays"`
 }
 
 func (op *FillOrderOperation) Type() OpType {
@@ -1086,7 +2100,7 @@ func (op *ShutdownWitnessOperation) Data() interface{} {
 type FillTransferFromSavingsOperation struct {
 	From      string `json:"from"`
 	To        string `json:"to"`
-	Amount    string `json:"amount"`
+	Amount    *Asset `json:"amount"`
 	RequestId uint32 `json:"request_id"`
 	Memo      string `json:"memo"`
 }
@@ -1126,7 +2140,7 @@ func (op *CommentPayoutUpdateOperation) Data() interface{} {
 
 type ReturnVestingDelegationOperation struct {
 	Account       string `json:"account"`
-	VestingShares string `json:"vesting_shares"`
+	VestingShares *Asset `json:"vesting_shares"`
 }
 
 func (op *ReturnVestingDelegationOperation) Type() OpType {
@@ -1141,7 +2155,7 @@ type CommentBenefactorRewardOperation struct {
 	Benefactor string `json:"benefactor"`
 	Author     string `json:"author"`
 	Permlink   string `json:"permlink"`
-	Reward     string `json:"reward"`
+	Reward     *Asset `json:"reward"`
 }
 
 func (op *CommentBenefactorRewardOperation) Type() OpType {
@@ -0,0 +1,553 @@
+package types
+
+import (
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// FC_REFLECT( scorum::protocol::transfer_to_scorumpower_operation,
+//             (from)
+//             (to)
+//             (amount) )
+
+type TransferToScorumpowerOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount *Asset `json:"amount"`
+}
+
+func (op *TransferToScorumpowerOperation) Type() OpType {
+	return TypeTransferToScorumpower
+}
+
+func (op *TransferToScorumpowerOperation) Data() interface{} {
+	return op
+}
+
+func (op *TransferToScorumpowerOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeTransferToScorumpower)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Amount)
+	return enc.Err()
+}
+
+func (op *TransferToScorumpowerOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	op.Amount = &Asset{}
+	dec.Decode(op.Amount)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::withdraw_scorumpower_operation,
+//             (account)
+//             (scorumpower) )
+
+type WithdrawScorumpowerOperation struct {
+	Account     string `json:"account"`
+	Scorumpower *Asset `json:"scorumpower"`
+}
+
+func (op *WithdrawScorumpowerOperation) Type() OpType {
+	return TypeWithdrawScorumpower
+}
+
+func (op *WithdrawScorumpowerOperation) Data() interface{} {
+	return op
+}
+
+func (op *WithdrawScorumpowerOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeWithdrawScorumpower)
+	enc.Encode(op.Account)
+	enc.Encode(op.Scorumpower)
+	return enc.Err()
+}
+
+func (op *WithdrawScorumpowerOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Account)
+	op.Scorumpower = &Asset{}
+	dec.Decode(op.Scorumpower)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::account_create_by_committee_operation,
+//             (creator)
+//             (new_account_name)
+//             (owner)
+//             (active)
+//             (posting)
+//             (memo_key)
+//             (json_metadata) )
+
+// AccountCreateByCommitteeOperation creates an account on behalf of the registration committee,
+// the creator must be a committee member.
+type AccountCreateByCommitteeOperation struct {
+	Creator        string     `json:"creator"`
+	NewAccountName string     `json:"new_account_name"`
+	Owner          *Authority `json:"owner"`
+	Active         *Authority `json:"active"`
+	Posting        *Authority `json:"posting"`
+	MemoKey        *PublicKey `json:"memo_key"`
+	JsonMetadata   string     `json:"json_metadata"`
+}
+
+func (op *AccountCreateByCommitteeOperation) Type() OpType {
+	return TypeAccountCreateByCommittee
+}
+
+func (op *AccountCreateByCommitteeOperation) Data() interface{} {
+	return op
+}
+
+func (op *AccountCreateByCommitteeOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAccountCreateByCommittee)
+	enc.Encode(op.Creator)
+	enc.Encode(op.NewAccountName)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Active)
+	enc.Encode(op.Posting)
+	enc.Encode(op.MemoKey)
+	enc.Encode(op.JsonMetadata)
+	return enc.Err()
+}
+
+func (op *AccountCreateByCommitteeOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op.Owner, op.Active, op.Posting = &Authority{}, &Authority{}, &Authority{}
+	op.MemoKey = &PublicKey{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Creator)
+	dec.Decode(&op.NewAccountName)
+	dec.Decode(op.Owner)
+	dec.Decode(op.Active)
+	dec.Decode(op.Posting)
+	dec.Decode(op.MemoKey)
+	dec.Decode(&op.JsonMetadata)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::set_withdraw_scorumpower_route_to_account_operation,
+//             (from_account)
+//             (to_account)
+//             (percent)
+//             (auto_vest) )
+
+type SetWithdrawScorumpowerRouteToAccountOperation struct {
+	FromAccount string `json:"from_account"`
+	ToAccount   string `json:"to_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+func (op *SetWithdrawScorumpowerRouteToAccountOperation) Type() OpType {
+	return TypeSetWithdrawScorumpowerRouteToAccount
+}
+
+func (op *SetWithdrawScorumpowerRouteToAccountOperation) Data() interface{} {
+	return op
+}
+
+func (op *SetWithdrawScorumpowerRouteToAccountOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeSetWithdrawScorumpowerRouteToAccount)
+	enc.Encode(op.FromAccount)
+	enc.Encode(op.ToAccount)
+	enc.Encode(op.Percent)
+	enc.EncodeBool(op.AutoVest)
+	return enc.Err()
+}
+
+func (op *SetWithdrawScorumpowerRouteToAccountOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.FromAccount)
+	dec.Decode(&op.ToAccount)
+	dec.Decode(&op.Percent)
+	dec.Decode(&op.AutoVest)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::set_withdraw_scorumpower_route_to_dev_pool_operation,
+//             (from_account)
+//             (percent)
+//             (auto_vest) )
+
+type SetWithdrawScorumpowerRouteToDevPoolOperation struct {
+	FromAccount string `json:"from_account"`
+	Percent     uint16 `json:"percent"`
+	AutoVest    bool   `json:"auto_vest"`
+}
+
+func (op *SetWithdrawScorumpowerRouteToDevPoolOperation) Type() OpType {
+	return TypeSetWithdrawScorumpowerRouteToDevPool
+}
+
+func (op *SetWithdrawScorumpowerRouteToDevPoolOperation) Data() interface{} {
+	return op
+}
+
+func (op *SetWithdrawScorumpowerRouteToDevPoolOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeSetWithdrawScorumpowerRouteToDevPool)
+	enc.Encode(op.FromAccount)
+	enc.Encode(op.Percent)
+	enc.EncodeBool(op.AutoVest)
+	return enc.Err()
+}
+
+func (op *SetWithdrawScorumpowerRouteToDevPoolOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.FromAccount)
+	dec.Decode(&op.Percent)
+	dec.Decode(&op.AutoVest)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::delegate_scorumpower_operation,
+//             (delegator)
+//             (delegatee)
+//             (scorumpower) )
+
+// DelegateScorumpowerOperation delegates SP from one account to another.
+// Delegating zero SP removes the delegation.
+type DelegateScorumpowerOperation struct {
+	Delegator   string `json:"delegator"`
+	Delegatee   string `json:"delegatee"`
+	Scorumpower *Asset `json:"scorumpower"`
+}
+
+func (op *DelegateScorumpowerOperation) Type() OpType {
+	return TypeDelegateScorumpower
+}
+
+func (op *DelegateScorumpowerOperation) Data() interface{} {
+	return op
+}
+
+func (op *DelegateScorumpowerOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeDelegateScorumpower)
+	enc.Encode(op.Delegator)
+	enc.Encode(op.Delegatee)
+	enc.Encode(op.Scorumpower)
+	return enc.Err()
+}
+
+func (op *DelegateScorumpowerOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Delegator)
+	dec.Decode(&op.Delegatee)
+	op.Scorumpower = &Asset{}
+	dec.Decode(op.Scorumpower)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::create_budget_operation,
+//             (owner)
+//             (content_permlink)
+//             (balance)
+//             (deadline) )
+
+type CreateBudgetOperation struct {
+	Owner           string `json:"owner"`
+	ContentPermlink string `json:"content_permlink"`
+	Balance         *Asset `json:"balance"`
+	Deadline        *Time  `json:"deadline"`
+}
+
+func (op *CreateBudgetOperation) Type() OpType {
+	return TypeCreateBudget
+}
+
+func (op *CreateBudgetOperation) Data() interface{} {
+	return op
+}
+
+func (op *CreateBudgetOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeCreateBudget)
+	enc.Encode(op.Owner)
+	enc.Encode(op.ContentPermlink)
+	enc.Encode(op.Balance)
+	enc.Encode(op.Deadline)
+	return enc.Err()
+}
+
+func (op *CreateBudgetOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op.Balance, op.Deadline = &Asset{}, &Time{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.ContentPermlink)
+	dec.Decode(op.Balance)
+	dec.Decode(op.Deadline)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::close_budget_operation,
+//             (budget_id)
+//             (owner) )
+
+type CloseBudgetOperation struct {
+	BudgetID int64  `json:"budget_id"`
+	Owner    string `json:"owner"`
+}
+
+func (op *CloseBudgetOperation) Type() OpType {
+	return TypeCloseBudget
+}
+
+func (op *CloseBudgetOperation) Data() interface{} {
+	return op
+}
+
+func (op *CloseBudgetOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeCloseBudget)
+	enc.Encode(op.BudgetID)
+	enc.Encode(op.Owner)
+	return enc.Err()
+}
+
+func (op *CloseBudgetOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.BudgetID)
+	dec.Decode(&op.Owner)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::proposal_vote_operation,
+//             (voting_account)
+//             (proposal_id) )
+
+type ProposalVoteOperation struct {
+	VotingAccount string `json:"voting_account"`
+	ProposalID    int64  `json:"proposal_id"`
+}
+
+func (op *ProposalVoteOperation) Type() OpType {
+	return TypeProposalVote
+}
+
+func (op *ProposalVoteOperation) Data() interface{} {
+	return op
+}
+
+func (op *ProposalVoteOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeProposalVote)
+	enc.Encode(op.VotingAccount)
+	enc.Encode(op.ProposalID)
+	return enc.Err()
+}
+
+func (op *ProposalVoteOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.VotingAccount)
+	dec.Decode(&op.ProposalID)
+	return dec.Err()
+}
+
+// AtomicSwapInitiateType tells whether the swap is being initiated by the initiator
+// or whether the participant is responding to the initiator.
+type AtomicSwapInitiateType string
+
+const (
+	AtomicSwapByInitiator   AtomicSwapInitiateType = "by_initiator"
+	AtomicSwapByParticipant AtomicSwapInitiateType = "by_participant"
+)
+
+var atomicSwapInitiateTypes = []string{
+	string(AtomicSwapByInitiator),
+	string(AtomicSwapByParticipant),
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (kind AtomicSwapInitiateType) MarshalTransaction(encoder *transaction.Encoder) error {
+	return encodeEnum(encoder, atomicSwapInitiateTypes, string(kind))
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (kind *AtomicSwapInitiateType) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	value, err := decodeEnum(decoder, atomicSwapInitiateTypes)
+	*kind = AtomicSwapInitiateType(value)
+	return err
+}
+
+// FC_REFLECT( scorum::protocol::atomicswap_initiate_operation,
+//             (type)
+//             (owner)
+//             (recipient)
+//             (amount)
+//             (secret_hash)
+//             (metadata) )
+
+type AtomicSwapInitiateOperation struct {
+	InitiateType AtomicSwapInitiateType `json:"type"`
+	Owner        string                 `json:"owner"`
+	Recipient    string                 `json:"recipient"`
+	Amount       *Asset                 `json:"amount"`
+	SecretHash   string                 `json:"secret_hash"`
+	Metadata     string                 `json:"metadata"`
+}
+
+func (op *AtomicSwapInitiateOperation) Type() OpType {
+	return TypeAtomicSwapInitiate
+}
+
+func (op *AtomicSwapInitiateOperation) Data() interface{} {
+	return op
+}
+
+func (op *AtomicSwapInitiateOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAtomicSwapInitiate)
+	enc.Encode(op.InitiateType)
+	enc.Encode(op.Owner)
+	enc.Encode(op.Recipient)
+	enc.Encode(op.Amount)
+	enc.Encode(op.SecretHash)
+	enc.Encode(op.Metadata)
+	return enc.Err()
+}
+
+func (op *AtomicSwapInitiateOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	op.Amount = &Asset{}
+
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.InitiateType)
+	dec.Decode(&op.Owner)
+	dec.Decode(&op.Recipient)
+	dec.Decode(op.Amount)
+	dec.Decode(&op.SecretHash)
+	dec.Decode(&op.Metadata)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::atomicswap_redeem_operation,
+//             (from)
+//             (to)
+//             (secret) )
+
+type AtomicSwapRedeemOperation struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Secret string `json:"secret"`
+}
+
+func (op *AtomicSwapRedeemOperation) Type() OpType {
+	return TypeAtomicSwapRedeem
+}
+
+func (op *AtomicSwapRedeemOperation) Data() interface{} {
+	return op
+}
+
+func (op *AtomicSwapRedeemOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAtomicSwapRedeem)
+	enc.Encode(op.From)
+	enc.Encode(op.To)
+	enc.Encode(op.Secret)
+	return enc.Err()
+}
+
+func (op *AtomicSwapRedeemOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.From)
+	dec.Decode(&op.To)
+	dec.Decode(&op.Secret)
+	return dec.Err()
+}
+
+// FC_REFLECT( scorum::protocol::atomicswap_refund_operation,
+//             (participant)
+//             (initiator)
+//             (secret_hash) )
+
+type AtomicSwapRefundOperation struct {
+	Participant string `json:"participant"`
+	Initiator   string `json:"initiator"`
+	SecretHash  string `json:"secret_hash"`
+}
+
+func (op *AtomicSwapRefundOperation) Type() OpType {
+	return TypeAtomicSwapRefund
+}
+
+func (op *AtomicSwapRefundOperation) Data() interface{} {
+	return op
+}
+
+func (op *AtomicSwapRefundOperation) MarshalTransaction(encoder *transaction.Encoder) error {
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.Encode(TypeAtomicSwapRefund)
+	enc.Encode(op.Participant)
+	enc.Encode(op.Initiator)
+	enc.Encode(op.SecretHash)
+	return enc.Err()
+}
+
+func (op *AtomicSwapRefundOperation) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&op.Participant)
+	dec.Decode(&op.Initiator)
+	dec.Decode(&op.SecretHash)
+	return dec.Err()
+}
+
+type ProducerRewardOperation struct {
+	Producer string `json:"producer"`
+	Reward   *Asset `json:"reward"`
+}
+
+func (op *ProducerRewardOperation) Type() OpType {
+	return TypeProducerReward
+}
+
+func (op *ProducerRewardOperation) Data() interface{} {
+	return op
+}
+
+type ReturnScorumpowerDelegationOperation struct {
+	Account     string `json:"account"`
+	Scorumpower *Asset `json:"scorumpower"`
+}
+
+func (op *ReturnScorumpowerDelegationOperation) Type() OpType {
+	return TypeReturnScorumpowerDelegation
+}
+
+func (op *ReturnScorumpowerDelegationOperation) Data() interface{} {
+	return op
+}
+
+// encodeEnum encodes a reflected enum value, which is serialized as int64.
+func encodeEnum(encoder *transaction.Encoder, values []string, value string) error {
+	for i, v := range values {
+		if v == value {
+			return encoder.EncodeNumber(int64(i))
+		}
+	}
+	return errors.Errorf("invalid enum value: %q", value)
+}
+
+// decodeEnum is the decoding counterpart of encodeEnum.
+func decodeEnum(decoder *transaction.Decoder, values []string) (string, error) {
+	var i int64
+	if err := decoder.DecodeNumber(&i); err != nil {
+		return "", err
+	}
+	if i < 0 || i >= int64(len(values)) {
+		return "", errors.Errorf("invalid enum value: %v", i)
+	}
+	return values[i], nil
+}
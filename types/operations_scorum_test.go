@@ -0,0 +1,253 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+func TestScorumOperations_MarshalTransaction(t *testing.T) {
+	t.Parallel()
+
+	authority := testKeyAuthority(testKey1)
+
+	deadline := time.Date(2018, 3, 2, 14, 13, 20, 0, time.UTC)
+	scr := func(amount int64) *Asset { return NewAsset(amount, ScorumPrecision, SymbolSCR) }
+	sp := func(amount int64) *Asset { return NewAsset(amount, ScorumPrecision, SymbolSP) }
+	secretHash := strings.Repeat("ab", 32)
+
+	// Source: none of the fixtures comes from a real Scorum transaction yet,
+	// they were derived by hand from the FC_REFLECT field order
+	// of the operations in scorum/scorum.
+	// A fixture replaced with chain data must cite the block number and the transaction ID.
+	tests := []struct {
+		op       Operation
+		expected string
+	}{
+		{
+			&TransferToScorumpowerOperation{From: "alice", To: "bob", Amount: scr(1000000000)},
+			"0305616c69636503626f6200ca9a3b000000000953435200000000",
+		},
+		{
+			&WithdrawScorumpowerOperation{Account: "alice", Scorumpower: sp(500000000)},
+			"0405616c6963650065cd1d000000000953500000000000",
+		},
+		{
+			&AccountCreateByCommitteeOperation{
+				Creator:        "alice",
+				NewAccountName: "dave",
+				Owner:          authority,
+				Active:         authority,
+				Posting:        authority,
+				MemoKey:        testKey2,
+			},
+			"0505616c696365046461766501000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9" +
+				"ef010001000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef01000100000000" +
+				"0103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef01000211111111111111111111111111" +
+				"1111111111111111111111111111111111111100",
+		},
+		{
+			&AccountCreateWithDelegationOperation{
+				Fee:            scr(0),
+				Delegation:     sp(1000),
+				Creator:        "alice",
+				NewAccountName: "dave",
+				Owner:          authority,
+				Active:         authority,
+				Posting:        authority,
+				MemoKey:        testKey2,
+				JsonMetadata:   "{}",
+			},
+			"0700000000000000000953435200000000e803000000000000095350000000000005616c69636504646176650100000000" +
+				"0103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef010001000000000103bc5cd80588b2" +
+				"3948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef010001000000000103bc5cd80588b23948aaa1e65be1a8" +
+				"b32cd9bed062a346c471c9319e62ba82a9ef0100021111111111111111111111111111111111111111111111111111111111" +
+				"111111027b7d00",
+		},
+		{
+			// Scorum serializes neither the fee nor the SBD interest rate.
+			&WitnessUpdateOperation{
+				Owner:           "alice",
+				Url:             "https://example.com",
+				BlockSigningKey: testKey1,
+				Props: &ChainProperties{
+					AccountCreationFee: scr(100000000),
+					MaximumBlockSize:   65536,
+				},
+			},
+			"0905616c6963651368747470733a2f2f6578616d706c652e636f6d03bc5cd80588b23948aaa1e65be1a8b32cd9bed062a3" +
+				"46c471c9319e62ba82a9ef00e1f50500000000095343520000000000000100",
+		},
+		{
+			// Scorum does not serialize percent_steem_dollars.
+			&CommentOptionsOperation{
+				Author:               "alice",
+				Permlink:             "hello",
+				MaxAcceptedPayout:    scr(1000000000),
+				AllowVotes:           true,
+				AllowCurationRewards: true,
+			},
+			"0d05616c6963650568656c6c6f00ca9a3b000000000953435200000000010100",
+		},
+		{
+			&SetWithdrawScorumpowerRouteToAccountOperation{FromAccount: "alice", ToAccount: "bob", Percent: 10000},
+			"0e05616c69636503626f62102700",
+		},
+		{
+			&SetWithdrawScorumpowerRouteToDevPoolOperation{FromAccount: "alice", Percent: 5000, AutoVest: true},
+			"0f05616c696365881301",
+		},
+		{
+			&DelegateScorumpowerOperation{Delegator: "alice", Delegatee: "bob", Scorumpower: sp(1000000000)},
+			"1505616c69636503626f6200ca9a3b000000000953500000000000",
+		},
+		{
+			&CreateBudgetOperation{
+				Owner:           "alice",
+				ContentPermlink: "my-post",
+				Balance:         scr(1000000000),
+				Deadline:        &Time{&deadline},
+			},
+			"1605616c696365076d792d706f737400ca9a3b000000000953435200000000005c995a",
+		},
+		{
+			&CloseBudgetOperation{BudgetID: 5, Owner: "alice"},
+			"17050000000000000005616c696365",
+		},
+		{
+			&ProposalVoteOperation{VotingAccount: "alice", ProposalID: 12},
+			"1805616c6963650c00000000000000",
+		},
+		{
+			&ProposalCreateOperation{
+				Creator:     "alice",
+				LifetimeSec: 86400,
+				Operation:   &RegistrationCommitteeAddMemberOperation{AccountName: "bob"},
+			},
+			"1905616c696365805101000003626f62",
+		},
+		{
+			&ProposalCreateOperation{
+				Creator:     "alice",
+				LifetimeSec: 86400,
+				Operation:   &DevelopmentCommitteeChangeQuorumOperation{Quorum: 60, CommitteeQuorum: QuorumAddMember},
+			},
+			"1905616c69636580510100053c000100000000000000",
+		},
+		{
+			&ProposalCreateOperation{
+				Creator:     "alice",
+				LifetimeSec: 86400,
+				Operation:   &DevelopmentCommitteeTransferOperation{ToAccount: "bob", Amount: scr(1000)},
+			},
+			"1905616c696365805101000703626f62e8030000000000000953435200000000",
+		},
+		{
+			&AtomicSwapInitiateOperation{
+				InitiateType: AtomicSwapByParticipant,
+				Owner:        "alice",
+				Recipient:    "bob",
+				Amount:       scr(1000),
+				SecretHash:   secretHash,
+			},
+			"1a010000000000000005616c69636503626f62e803000000000000095343520000000040" +
+				hex.EncodeToString([]byte(secretHash)) + "00",
+		},
+		{
+			&AtomicSwapRedeemOperation{From: "alice", To: "bob", Secret: "secret"},
+			"1b05616c69636503626f6206736563726574",
+		},
+		{
+			&AtomicSwapRefundOperation{Participant: "alice", Initiator: "bob", SecretHash: secretHash},
+			"1c05616c69636503626f6240" + hex.EncodeToString([]byte(secretHash)),
+		},
+	}
+
+	for _, test := range tests {
+		testRoundTrip(t, test.op, test.expected)
+	}
+}
+
+func TestOpCatalog(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		catalog *OpCatalog
+		kind    OpType
+		code    uint16
+		ok      bool
+	}{
+		{ScorumOpCatalog, TypeVote, 0, true},
+		{ScorumOpCatalog, TypeAccountCreate, 6, true},
+		{ScorumOpCatalog, TypeDelegateScorumpower, 21, true},
+		{ScorumOpCatalog, TypeEscrowTransfer, 0, false},
+		{SteemOpCatalog, TypeAccountCreate, 9, true},
+		{SteemOpCatalog, TypeAccountCreateWithDelegation, 41, true},
+		{SteemOpCatalog, TypeDelegateScorumpower, 0, false},
+	}
+
+	for _, test := range tests {
+		code, ok := test.catalog.Code(test.kind)
+		if code != test.code || ok != test.ok {
+			t.Errorf("%v: %v: expected %v %v, got %v %v",
+				test.catalog.Name(), test.kind, test.code, test.ok, code, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if kind, _ := test.catalog.Type(code); kind != test.kind {
+			t.Errorf("%v: %v: expected %v, got %v", test.catalog.Name(), code, test.kind, kind)
+		}
+	}
+
+	// Operations not present in the catalog cannot be encoded,
+	// ScorumOpCatalog is used when no catalog is set.
+	op := &EscrowDisputeOperation{From: "alice", To: "bob", Agent: "carol", Who: "alice", EscrowId: 1}
+	if err := transaction.NewEncoder(&bytes.Buffer{}).Encode(op); err == nil {
+		t.Error("expected an error for an operation missing in the catalog")
+	}
+	steem := transaction.SetCatalog(SteemOpCatalog)
+	if err := transaction.NewEncoder(&bytes.Buffer{}, steem).Encode(op); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestProposalCreateOperation_JSON(t *testing.T) {
+	data := `{"creator":"alice","lifetime_sec":86400,` +
+		`"operation":["registration_committee_change_quorum",{"quorum":60,"committee_quorum":"base_quorum"}]}`
+
+	var op ProposalCreateOperation
+	if err := json.Unmarshal([]byte(data), &op); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &RegistrationCommitteeChangeQuorumOperation{Quorum: 60, CommitteeQuorum: QuorumBase}
+	if op.Creator != "alice" || op.LifetimeSec != 86400 || !reflect.DeepEqual(op.Operation, expected) {
+		t.Errorf("unexpected operation: %+v", op)
+	}
+
+	out, err := json.Marshal(&op)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip ProposalCreateOperation
+	if err := json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&roundTrip, &op) {
+		t.Errorf("round trip: expected %+v, got %+v", op, roundTrip)
+	}
+
+	if err := json.Unmarshal([]byte(`{"operation":["unknown",{}]}`), &op); err == nil {
+		t.Error("expected an error for an unknown proposal operation")
+	}
+}
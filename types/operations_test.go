@@ -4,12 +4,16 @@ import (
 	// Stdlib
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"reflect"
 	"testing"
+	"time"
 
 	// RPC
 	"github.com/goscorum/scorumgo/encoding/transaction"
 )
 
+// The vote is the reference vector of python-steem, see xeroc/piston.
 func TestVoteOperation_MarshalTransaction(t *testing.T) {
 	op := &VoteOperation{
 		Voter:    "xeroc",
@@ -33,3 +37,373 @@ func TestVoteOperation_MarshalTransaction(t *testing.T) {
 		t.Errorf("expected %v, got %v", expectedHex, serializedHex)
 	}
 }
+
+func TestOperations_MarshalTransaction(t *testing.T) {
+	t.Parallel()
+
+	// The fixtures use the Steem operation codes and layouts.
+	//
+	// Source: none of them comes from a real transaction or a reference
+	// test suite yet, they were derived by hand from the FC_REFLECT field order
+	// of the operations in steemit/steem.
+	// The only reference vector is the vote in TestVoteOperation_MarshalTransaction.
+	// A fixture replaced with chain data must cite the block number and the transaction ID.
+	steem := transaction.SetCatalog(SteemOpCatalog)
+
+	timestamp := time.Date(2018, 3, 2, 14, 13, 20, 0, time.UTC)
+	deadline := timestamp.Add(24 * time.Hour)
+	expiration := &Time{&timestamp}
+
+	sbd := func(amount int64) *Asset { return NewAsset(amount, 3, "SBD") }
+	scr := func(amount int64) *Asset { return NewAsset(amount, ScorumPrecision, SymbolSCR) }
+	sp := func(amount int64) *Asset { return NewAsset(amount, ScorumPrecision, SymbolSP) }
+
+	tests := []struct {
+		op       Operation
+		expected string
+	}{
+		{
+			&TransferToVestingOperation{From: "alice", To: "bob", Amount: scr(1000000000)},
+			"0305616c69636503626f6200ca9a3b000000000953435200000000",
+		},
+		{
+			&WithdrawVestingOperation{Account: "alice", VestingShares: sp(500000000)},
+			"0405616c6963650065cd1d000000000953500000000000",
+		},
+		{
+			&LimitOrderCreateOperation{
+				Owner:        "alice",
+				OrderID:      42,
+				AmountToSell: scr(1000),
+				MinToReceive: sbd(2000),
+				Expiration:   expiration,
+			},
+			"0505616c6963652a000000e8030000000000000953435200000000d007000000000000035342440000000000005c995a",
+		},
+		{
+			&LimitOrderCancelOperation{Owner: "alice", OrderID: 42},
+			"0605616c6963652a000000",
+		},
+		{
+			&FeedPublishOperation{Publisher: "alice", ExchangeRate: &Price{Base: sbd(1000), Quote: scr(1000000000)}},
+			"0705616c696365e803000000000000035342440000000000ca9a3b000000000953435200000000",
+		},
+		{
+			&ConvertOperation{Owner: "alice", RequestID: 7, Amount: sbd(1000)},
+			"0805616c69636507000000e8030000000000000353424400000000",
+		},
+		{
+			&AccountCreateOperation{
+				Fee:            scr(100000000),
+				Creator:        "alice",
+				NewAccountName: "dave",
+				Owner:          testAuthority,
+				Active:         testAuthority,
+				Posting:        testAuthority,
+				MemoKey:        testKey1,
+				JsonMetadata:   "{}",
+			},
+			"0900e1f50500000000095343520000000005616c6963650464617665010000000203626f620200056361726f6c01000202" +
+				"1111111111111111111111111111111111111111111111111111111111111111010003bc5cd80588b23948aaa1e65be1a8b3" +
+				"2cd9bed062a346c471c9319e62ba82a9ef0100010000000203626f620200056361726f6c0100020211111111111111111111" +
+				"11111111111111111111111111111111111111111111010003bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9" +
+				"319e62ba82a9ef0100010000000203626f620200056361726f6c010002021111111111111111111111111111111111111111" +
+				"111111111111111111111111010003bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef010003" +
+				"bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef027b7d",
+		},
+		{
+			&AccountUpdateOperation{Account: "alice", Owner: testKeyAuthority(testKey1), MemoKey: testKey2},
+			"0a05616c6963650101000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef01" +
+				"00000002111111111111111111111111111111111111111111111111111111111111111100",
+		},
+		{
+			&WitnessUpdateOperation{
+				Owner:           "alice",
+				Url:             "https://example.com",
+				BlockSigningKey: testKey1,
+				Props: &ChainProperties{
+					AccountCreationFee: scr(100000000),
+					MaximumBlockSize:   65536,
+					SBDInterestRate:    1000,
+				},
+				Fee: scr(0),
+			},
+			"0b05616c6963651368747470733a2f2f6578616d706c652e636f6d03bc5cd80588b23948aaa1e65be1a8b32cd9bed062a3" +
+				"46c471c9319e62ba82a9ef00e1f50500000000095343520000000000000100e80300000000000000000953435200000000",
+		},
+		{
+			&AccountWitnessProxyOperation{Account: "alice", Proxy: "bob"},
+			"0d05616c69636503626f62",
+		},
+		{
+			&CustomOperation{RequiredAuths: []string{"alice"}, Id: 7, Datas: []byte{1, 2, 3}},
+			"0f0105616c696365070003010203",
+		},
+		{
+			&SetWithdrawVestingRouteOperation{FromAccount: "alice", ToAccount: "bob", Percent: 5000, AutoVest: true},
+			"1405616c69636503626f62881301",
+		},
+		{
+			&LimitOrderCreate2Operation{
+				Qwner:        "alice",
+				Orderid:      43,
+				AmountToSell: scr(1000),
+				ExchangeRate: &Price{Base: sbd(1000), Quote: scr(2000)},
+				FillOrKill:   true,
+				Expiration:   expiration,
+			},
+			"1505616c6963652b000000e8030000000000000953435200000000e8030000000000000353424400000000d00700000000" +
+				"0000095343520000000001005c995a",
+		},
+		{
+			&ChallengeAuthorityOperation{Challenger: "alice", Challenged: "bob", RequireOwner: true},
+			"1605616c69636503626f6201",
+		},
+		{
+			&ProveAuthorityOperation{Challenged: "bob"},
+			"1703626f6200",
+		},
+		{
+			&RequestAccountRecoveryOperation{
+				RecoveryAccount:   "alice",
+				AccountToRecover:  "bob",
+				NewOwnerAuthority: *testKeyAuthority(testKey1),
+			},
+			"1805616c69636503626f6201000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef010000",
+		},
+		{
+			&RecoverAccountOperation{
+				AccountToRecover:     "bob",
+				NewOwnerAuthority:    *testKeyAuthority(testKey1),
+				RecentOwnerAuthority: *testKeyAuthority(testKey2),
+			},
+			"1903626f6201000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef0100010000" +
+				"000001021111111111111111111111111111111111111111111111111111111111111111010000",
+		},
+		{
+			&ChangeRecoveryAccountOperation{AccountToRecover: "bob", NewRecoveryAccount: "carol"},
+			"1a03626f62056361726f6c00",
+		},
+		{
+			&EscrowTransferOperation{
+				From:                 "alice",
+				To:                   "bob",
+				SbdAmount:            sbd(1000),
+				SteemAmount:          scr(0),
+				EscrowId:             23,
+				Agent:                "carol",
+				Fee:                  scr(1000),
+				JsonMeta:             "{}",
+				RatificationDeadline: expiration,
+				EscrowExpiration:     &Time{&deadline},
+			},
+			"1b05616c69636503626f62e80300000000000003534244000000000000000000000000095343520000000017000000056361" +
+				"726f6ce8030000000000000953435200000000027b7d005c995a80ad9a5a",
+		},
+		{
+			&EscrowDisputeOperation{From: "alice", To: "bob", Agent: "carol", Who: "alice", EscrowId: 23},
+			"1c05616c69636503626f62056361726f6c05616c69636517000000",
+		},
+		{
+			&EscrowReleaseOperation{
+				From:        "alice",
+				To:          "bob",
+				Agent:       "carol",
+				Who:         "carol",
+				Receiver:    "bob",
+				EscrowId:    23,
+				SbdAmount:   sbd(1000),
+				SteemAmount: scr(0),
+			},
+			"1d05616c69636503626f62056361726f6c056361726f6c03626f6217000000e8030000000000000353424400000000000000" +
+				"00000000000953435200000000",
+		},
+		{
+			&EscrowApproveOperation{From: "alice", To: "bob", Agent: "carol", Who: "carol", EscrowId: 23, Approve: true},
+			"1f05616c69636503626f62056361726f6c056361726f6c1700000001",
+		},
+		{
+			&TransferFromSavingsOperation{From: "alice", RequestId: 3, To: "bob", Amount: scr(1000), Memo: "memo"},
+			"2105616c6963650300000003626f62e8030000000000000953435200000000046d656d6f",
+		},
+		{
+			&CancelTransferFromSavingsOperation{From: "alice", RequestId: 3},
+			"2205616c69636503000000",
+		},
+		{
+			&CustomBinaryOperation{
+				RequiredActiveAuths: []string{"alice"},
+				RequiredAuths: []*Authority{
+					{WeightThreshold: 1, AccountAuths: []*Auth{{Key: "alice", Check: 1}}},
+				},
+				Id:    "app",
+				Datas: []byte{0xca, 0xfe},
+			},
+			"23000105616c6963650001010000000105616c6963650100000361707002cafe",
+		},
+		{
+			&DeclineVotingRightsOperation{Account: "alice", Decline: true},
+			"2405616c69636501",
+		},
+		{
+			&ResetAccountOperation{
+				ResetAccount:      "bob",
+				AccountToReset:    "alice",
+				NewOwnerAuthority: testKeyAuthority(testKey1),
+			},
+			"2503626f6205616c69636501000000000103bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef0100",
+		},
+		{
+			&SetResetAccountOperation{Account: "alice", CurrentResetAccount: "bob", ResetAccount: "carol"},
+			"2605616c69636503626f62056361726f6c",
+		},
+		{
+			&ClaimRewardBalanceOperation{Account: "alice", RewardSteem: scr(1), RewardSbd: sbd(0), RewardVests: sp(2)},
+			"2705616c6963650100000000000000095343520000000000000000000000000353424400000000020000000000000009535000" +
+				"00000000",
+		},
+		{
+			&DelegateVestingSharesOperation{Delegator: "alice", Delegatee: "bob", VestingShares: sp(1000000000)},
+			"2805616c69636503626f6200ca9a3b000000000953500000000000",
+		},
+	}
+
+	for _, test := range tests {
+		testRoundTrip(t, test.op, test.expected, steem)
+	}
+}
+
+func TestDecodeOperation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Operation
+	}{
+		{
+			"00057865726f63057865726f6306706973746f6e1027",
+			&VoteOperation{Voter: "xeroc", Author: "xeroc", Permlink: "piston", Weight: 10000},
+		},
+		{
+			"0203666f6f03626172e80300000000000003535445454d000000",
+			&TransferOperation{From: "foo", To: "bar", Amount: NewAsset(1000, 3, "STEEM")},
+		},
+	}
+
+	for _, test := range tests {
+		raw, _ := hex.DecodeString(test.input)
+		op, err := DecodeOperation(transaction.NewDecoder(bytes.NewReader(raw)))
+		if err != nil {
+			t.Errorf("%v: %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(op, test.expected) {
+			t.Errorf("%v: expected %+v, got %+v", test.input, test.expected, op)
+		}
+	}
+}
+
+func TestDecodeOperation_Invalid(t *testing.T) {
+	t.Parallel()
+
+	// The fixtures use the Steem operation codes.
+	steem := transaction.SetCatalog(SteemOpCatalog)
+
+	for _, input := range []string{
+		// Truncated vote.
+		"00057865726f63057865726f6306706973746f6e10",
+		// Unknown operation code.
+		"ff01",
+		// pow is not supported.
+		"0e",
+		// Invalid bool in account_witness_vote.
+		"0c05616c69636503626f6202",
+		// Non-empty extensions in change_recovery_account.
+		"1a03626f62056361726f6c01",
+	} {
+		raw, _ := hex.DecodeString(input)
+		if op, err := DecodeOperation(transaction.NewDecoder(bytes.NewReader(raw), steem)); err == nil {
+			t.Errorf("%v: expected an error, got %+v", input, op)
+		}
+	}
+}
+
+func TestOperations_MarshalTransaction_Invalid(t *testing.T) {
+	t.Parallel()
+
+	// The fixtures use the Steem operation codes.
+	steem := transaction.SetCatalog(SteemOpCatalog)
+
+	tests := []Operation{
+		&WithdrawVestingOperation{Account: "alice"},
+		&AccountCreateOperation{Fee: NewAsset(0, 9, SymbolSCR), Creator: "alice", NewAccountName: "bob"},
+		&ChangeRecoveryAccountOperation{Extensions: []interface{}{"unknown"}},
+		&ResetAccountOperation{NewOwnerAuthority: &Authority{KeyAuths: []*KeyAuth{{Check: 1}}}},
+		&AccountUpdateOperation{Owner: &Authority{AccountAuths: []*Auth{{Key: "alice", Check: 1 << 16}}}},
+	}
+
+	for _, op := range tests {
+		var b bytes.Buffer
+		if err := transaction.NewEncoder(&b, steem).Encode(op); err == nil {
+			t.Errorf("%v: expected an error", op.Type())
+		}
+	}
+}
+
+func TestOperations_JSON_UnknownOperation(t *testing.T) {
+	input := `[` +
+		`["vote",{"voter":"alice","author":"bob","permlink":"hello","weight":10000}],` +
+		`["vote",{"voter":"alice","weight":"invalid"}],` +
+		`["future_operation",{"b":[1,2.50,{"c":"<d>"}],"a":null}]` +
+		`]`
+
+	var ops Operations
+	if err := json.Unmarshal([]byte(input), &ops); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ops[0].(*VoteOperation); !ok {
+		t.Errorf("expected *VoteOperation, got %T", ops[0])
+	}
+
+	unknown, ok := ops[2].(*UnknownOperation)
+	if !ok {
+		t.Fatalf("expected *UnknownOperation, got %T", ops[2])
+	}
+	if unknown.Type() != "future_operation" {
+		t.Errorf("unexpected operation type: %v", unknown.Type())
+	}
+	if raw := string(unknown.RawJSON()); raw != `{"b":[1,2.50,{"c":"<d>"}],"a":null}` {
+		t.Errorf("unexpected raw JSON: %v", raw)
+	}
+
+	output, err := ops.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(bytes.TrimSpace(output)); got != input {
+		t.Errorf("expected %v, got %v", input, got)
+	}
+}
+
+func TestOperations_JSON_UnknownOperationWhitespace(t *testing.T) {
+	body := "{\n  \"a\": [1, 2],\n  \"b\": \"<c d>\"\n}"
+	input := `[["future_operation",` + body + `]]`
+
+	var ops Operations
+	if err := json.Unmarshal([]byte(input), &ops); err != nil {
+		t.Fatal(err)
+	}
+
+	// The body is kept as received.
+	if raw := string(ops[0].(*UnknownOperation).RawJSON()); raw != body {
+		t.Errorf("expected %q, got %q", body, raw)
+	}
+
+	// Marshalling compacts it, strings are kept as they are.
+	expected := `[["future_operation",{"a":[1,2],"b":"<c d>"}]]`
+	output, err := JSONMarshal(ops)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(bytes.TrimSpace(output)); got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -1,11 +1,25 @@
 package types
 
-// OpType represents a Steem operation type, i.e. vote, comment, pow and so on.
+import (
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// OpType represents an operation type, i.e. vote, comment, pow and so on.
 type OpType string
 
-// Code returns the operation code associated with the given operation type.
-func (kind OpType) Code() uint16 {
-	return opCodes[kind]
+// MarshalTransaction implements transaction.TransactionMarshaller.
+// The operation code from the encoder catalog is encoded, see transaction.SetCatalog.
+func (kind OpType) MarshalTransaction(encoder *transaction.Encoder) error {
+	catalog := encoderOpCatalog(encoder)
+	code, ok := catalog.Code(kind)
+	if !ok {
+		return errors.Errorf("operation not supported by the %v catalog: %v", catalog.Name(), kind)
+	}
+	return encoder.EncodeUVarint(uint64(code))
 }
 
 const (
@@ -67,7 +81,27 @@ const (
 	TypeCommentBenefactorReward     OpType = "comment_benefactor_reward"
 )
 
-var opTypes = [...]OpType{
+// Scorum operation types.
+const (
+	TypeTransferToScorumpower                OpType = "transfer_to_scorumpower"
+	TypeWithdrawScorumpower                  OpType = "withdraw_scorumpower"
+	TypeAccountCreateByCommittee             OpType = "account_create_by_committee"
+	TypeSetWithdrawScorumpowerRouteToAccount OpType = "set_withdraw_scorumpower_route_to_account"
+	TypeSetWithdrawScorumpowerRouteToDevPool OpType = "set_withdraw_scorumpower_route_to_dev_pool"
+	TypeDelegateScorumpower                  OpType = "delegate_scorumpower"
+	TypeCreateBudget                         OpType = "create_budget"
+	TypeCloseBudget                          OpType = "close_budget"
+	TypeProposalVote                         OpType = "proposal_vote"
+	TypeProposalCreate                       OpType = "proposal_create"
+	TypeAtomicSwapInitiate                   OpType = "atomicswap_initiate"
+	TypeAtomicSwapRedeem                     OpType = "atomicswap_redeem"
+	TypeAtomicSwapRefund                     OpType = "atomicswap_refund"
+	TypeProducerReward                       OpType = "producer_reward"
+	TypeReturnScorumpowerDelegation          OpType = "return_scorumpower_delegation"
+)
+
+// steemOpTypes lists the Steem operations in the order of the operation static_variant.
+var steemOpTypes = []OpType{
 	TypeVote,
 	TypeComment,
 	TypeTransfer,
@@ -109,7 +143,7 @@ var opTypes = [...]OpType{
 	TypeSetResetAccount,
 	TypeClaimRewardBalance,
 	TypeDelegateVestingShares,
-	//TypeAccountCreateWithDelegation,
+	TypeAccountCreateWithDelegation,
 	TypeFillConvertRequest,
 	TypeAuthorReward,
 	TypeCurationReward,
@@ -126,12 +160,73 @@ var opTypes = [...]OpType{
 	TypeCommentBenefactorReward,
 }
 
-// opCodes keeps mapping operation type -> operation code.
-var opCodes map[OpType]uint16
+// scorumOpTypes lists the Scorum operations in the order of the operation static_variant.
+var scorumOpTypes = []OpType{
+	TypeVote,
+	TypeComment,
+	TypeTransfer,
+	TypeTransferToScorumpower,
+	TypeWithdrawScorumpower,
+	TypeAccountCreateByCommittee,
+	TypeAccountCreate,
+	TypeAccountCreateWithDelegation,
+	TypeAccountUpdate,
+	TypeWitnessUpdate,
+	TypeAccountWitnessVote,
+	TypeAccountWitnessProxy,
+	TypeDeleteComment,
+	TypeCommentOptions,
+	TypeSetWithdrawScorumpowerRouteToAccount,
+	TypeSetWithdrawScorumpowerRouteToDevPool,
+	TypeProveAuthority,
+	TypeRequestAccountRecovery,
+	TypeRecoverAccount,
+	TypeChangeRecoveryAccount,
+	TypeDeclineVotingRights,
+	TypeDelegateScorumpower,
+	TypeCreateBudget,
+	TypeCloseBudget,
+	TypeProposalVote,
+	TypeProposalCreate,
+	TypeAtomicSwapInitiate,
+	TypeAtomicSwapRedeem,
+	TypeAtomicSwapRefund,
+	TypeAuthorReward,
+	TypeCommentBenefactorReward,
+	TypeCommentPayoutUpdate,
+	TypeCommentReward,
+	TypeCurationReward,
+	TypeHardfork,
+	TypeProducerReward,
+	TypeReturnScorumpowerDelegation,
+	TypeShutdownWitness,
+}
+
+// virtualOpTypes keeps the operation types generated by the blockchain itself.
+// Virtual operations are never part of a transaction, they are only returned
+// by get_ops_in_block and get_account_history.
+var virtualOpTypes = map[OpType]bool{
+	TypeFillConvertRequest:      true,
+	TypeAuthorReward:            true,
+	TypeCurationReward:          true,
+	TypeCommentReward:           true,
+	TypeLiquidityReward:         true,
+	TypeInterest:                true,
+	TypeFillVestingWithdraw:     true,
+	TypeFillOrder:               true,
+	TypeShutdownWitness:         true,
+	TypeFillTransferFromSavings: true,
+	TypeHardfork:                true,
+	TypeCommentPayoutUpdate:     true,
+	TypeReturnVestingDelegation: true,
+	TypeCommentBenefactorReward: true,
 
-func init() {
-	opCodes = make(map[OpType]uint16, len(opTypes))
-	for i, opType := range opTypes {
-		opCodes[opType] = uint16(i)
-	}
+	TypeProducerReward:              true,
+	TypeReturnScorumpowerDelegation: true,
+}
+
+// IsVirtual returns true for operations generated by the blockchain itself,
+// e.g. author_reward, as opposed to operations broadcasted in transactions.
+func (kind OpType) IsVirtual() bool {
+	return virtualOpTypes[kind]
 }
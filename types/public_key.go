@@ -0,0 +1,165 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/asuleymanov/btc/btcutil/base58"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ripemd160"
+)
+
+// DefaultAddressPrefix is the public key prefix used by the Scorum mainnet.
+// The prefix used by a node is available as Config.ScorumAddressPrefix.
+const DefaultAddressPrefix = "SCR"
+
+const (
+	publicKeySize     = 33
+	publicKeyChecksum = 4
+)
+
+// PublicKey represents a public key, e.g. "SCR8GC13uCZbP44HzMLV6zPZGwVQ8Nt4Kji8PapsPiNq1BK153XTX".
+//
+// The text form is the address prefix followed by base58 of the compressed key
+// with the first 4 bytes of ripemd160 of the key appended as a checksum.
+// The binary form used in transactions is the 33-byte compressed key.
+type PublicKey struct {
+	key    [publicKeySize]byte
+	prefix string
+}
+
+// NewPublicKey creates a public key from the 33-byte compressed form,
+// e.g. as returned by wif.GetPublicKey. The prefix is used for formatting.
+func NewPublicKey(compressed []byte, prefix string) (*PublicKey, error) {
+	if len(compressed) != publicKeySize {
+		return nil, errors.Errorf("public key must be %v bytes long, got %v", publicKeySize, len(compressed))
+	}
+
+	key := &PublicKey{prefix: prefix}
+	copy(key.key[:], compressed)
+	return key, nil
+}
+
+// ParsePublicKey parses a public key string with the given prefix,
+// e.g. Config.ScorumAddressPrefix.
+func ParsePublicKey(s, prefix string) (*PublicKey, error) {
+	if !strings.HasPrefix(s, prefix) {
+		return nil, errors.Errorf("invalid public key: %v: expected prefix %v", s, prefix)
+	}
+
+	key, ok := decodePublicKey(s[len(prefix):])
+	if !ok {
+		return nil, errors.Errorf("invalid public key: %v", s)
+	}
+	return NewPublicKey(key, prefix)
+}
+
+// MustParsePublicKey is like ParsePublicKey, but it panics on error.
+// It is meant to be used for constants.
+func MustParsePublicKey(s, prefix string) *PublicKey {
+	key, err := ParsePublicKey(s, prefix)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// decodePublicKey decodes the base58 part of a public key string and checks the checksum.
+func decodePublicKey(s string) ([]byte, bool) {
+	raw := base58.Decode(s)
+	if len(raw) != publicKeySize+publicKeyChecksum {
+		return nil, false
+	}
+
+	key, checksum := raw[:publicKeySize], raw[publicKeySize:]
+	if !bytes.Equal(publicKeyChecksumOf(key), checksum) {
+		return nil, false
+	}
+	return key, true
+}
+
+func publicKeyChecksumOf(key []byte) []byte {
+	hash := ripemd160.New()
+	hash.Write(key)
+	return hash.Sum(nil)[:publicKeyChecksum]
+}
+
+// Bytes returns the 33-byte compressed form of the key.
+func (key *PublicKey) Bytes() []byte {
+	return append([]byte(nil), key.key[:]...)
+}
+
+// Prefix returns the address prefix the key is formatted with.
+func (key *PublicKey) Prefix() string {
+	return key.prefix
+}
+
+// WithPrefix returns a copy of the key formatted with the given prefix.
+func (key *PublicKey) WithPrefix(prefix string) *PublicKey {
+	return &PublicKey{key: key.key, prefix: prefix}
+}
+
+// Equal returns true when both keys are the same, the prefix is not compared.
+func (key *PublicKey) Equal(other *PublicKey) bool {
+	if key == nil || other == nil {
+		return key == other
+	}
+	return key.key == other.key
+}
+
+// String returns the text form of the key.
+func (key *PublicKey) String() string {
+	raw := append(key.Bytes(), publicKeyChecksumOf(key.key[:])...)
+	return key.prefix + base58.Encode(raw)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (key *PublicKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(key.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// The prefix is not known in advance, so the key is accepted with any prefix
+// and the prefix is kept for formatting.
+func (key *PublicKey) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal public key: %v", string(data))
+	}
+
+	// Try the prefix lengths one by one until the rest is a valid key.
+	for i := 0; i < len(s); i++ {
+		if raw, ok := decodePublicKey(s[i:]); ok {
+			parsed, err := NewPublicKey(raw, s[:i])
+			if err != nil {
+				return err
+			}
+			*key = *parsed
+			return nil
+		}
+	}
+	return errors.Errorf("invalid public key: %v", s)
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (key *PublicKey) MarshalTransaction(encoder *transaction.Encoder) error {
+	if key == nil {
+		return errors.New("public key not set")
+	}
+	return encoder.EncodeRaw(key.key[:])
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+// The binary form carries no prefix, DefaultAddressPrefix is used,
+// WithPrefix can be used to change it.
+func (key *PublicKey) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	key.prefix = DefaultAddressPrefix
+	return decoder.DecodeRaw(key.key[:])
+}
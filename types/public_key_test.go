@@ -0,0 +1,108 @@
+package types
+
+import (
+	// Stdlib
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+)
+
+const (
+	testPublicKey    = "STM8GC13uCZbP44HzMLV6zPZGwVQ8Nt4Kji8PapsPiNq1BK153XTX"
+	testPublicKeyHex = "03bc5cd80588b23948aaa1e65be1a8b32cd9bed062a346c471c9319e62ba82a9ef"
+)
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := ParsePublicKey(testPublicKey, "STM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(key.Bytes()); got != testPublicKeyHex {
+		t.Errorf("expected %v, got %v", testPublicKeyHex, got)
+	}
+	if got := key.String(); got != testPublicKey {
+		t.Errorf("expected %v, got %v", testPublicKey, got)
+	}
+
+	scr := key.WithPrefix(DefaultAddressPrefix)
+	if scr.String() != "SCR"+testPublicKey[3:] {
+		t.Errorf("unexpected key: %v", scr)
+	}
+	if !scr.Equal(key) {
+		t.Error("expected the keys to be equal regardless of the prefix")
+	}
+
+	raw, _ := hex.DecodeString(testPublicKeyHex)
+	raw[1]++
+	other, err := NewPublicKey(raw, "STM")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Equal(key) {
+		t.Error("expected the keys to differ")
+	}
+}
+
+func TestParsePublicKey_Invalid(t *testing.T) {
+	for _, input := range []struct{ key, prefix string }{
+		{testPublicKey, "SCR"},
+		{testPublicKey[:len(testPublicKey)-1] + "Y", "STM"},
+		{"STM", "STM"},
+		{"", "STM"},
+	} {
+		if key, err := ParsePublicKey(input.key, input.prefix); err == nil {
+			t.Errorf("%q: expected an error, got %v", input.key, key)
+		}
+	}
+}
+
+func TestPublicKey_JSON(t *testing.T) {
+	var op AccountUpdateOperation
+	data := `{"account":"alice","memo_key":"` + testPublicKey + `","json_metadata":""}`
+	if err := json.Unmarshal([]byte(data), &op); err != nil {
+		t.Fatal(err)
+	}
+	if op.MemoKey.Prefix() != "STM" || hex.EncodeToString(op.MemoKey.Bytes()) != testPublicKeyHex {
+		t.Errorf("unexpected key: %v", op.MemoKey)
+	}
+
+	out, err := json.Marshal(op.MemoKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != `"`+testPublicKey+`"` {
+		t.Errorf("unexpected JSON: %v", string(out))
+	}
+
+	// The all-zero key is used to disable block production.
+	var key PublicKey
+	if err := json.Unmarshal([]byte(`"STM1111111111111111111111111111111114T1Anm"`), &key); err != nil {
+		t.Fatal(err)
+	}
+	if key.Prefix() != "STM" || !bytes.Equal(key.Bytes(), make([]byte, 33)) {
+		t.Errorf("unexpected key: %v", key.String())
+	}
+
+	if err := json.Unmarshal([]byte(`"STMinvalid"`), &key); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestPublicKey_MarshalTransaction(t *testing.T) {
+	var b bytes.Buffer
+	if err := transaction.NewEncoder(&b).Encode(MustParsePublicKey(testPublicKey, "STM")); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != testPublicKeyHex {
+		t.Errorf("expected %v, got %v", testPublicKeyHex, got)
+	}
+
+	var key *PublicKey
+	if err := transaction.NewEncoder(&b).Encode(key); err == nil {
+		t.Error("expected an error for a missing key")
+	}
+}
@@ -6,6 +6,9 @@ import (
 
 	// RPC
 	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
 )
 
 const Layout = `"2006-01-02T15:04:05"`
@@ -28,9 +31,22 @@ func (t *Time) UnmarshalJSON(data []byte) error {
 }
 
 func (t *Time) MarshalTransaction(encoder *transaction.Encoder) error {
+	if t == nil || t.Time == nil {
+		return errors.New("time not set")
+	}
 	return encoder.Encode(uint32(t.Time.Unix()))
 }
 
+func (t *Time) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	parsed := time.Unix(int64(v), 0).UTC()
+	t.Time = &parsed
+	return nil
+}
+
 func (t *Time) Scan(v interface{}) error {
 	// Should be more strictly to check this type.
 	vt, err := time.Parse("2006-01-02 15:04:05", string(v.([]byte)))
@@ -1,6 +1,11 @@
 package types
 
 import (
+	// Stdlib
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
 	// RPC
 	"github.com/goscorum/scorumgo/encoding/transaction"
 
@@ -10,11 +15,12 @@ import (
 
 // Transaction represents a blockchain transaction.
 type Transaction struct {
-	RefBlockNum    UInt16     `json:"ref_block_num"`
-	RefBlockPrefix UInt32     `json:"ref_block_prefix"`
-	Expiration     *Time      `json:"expiration"`
-	Operations     Operations `json:"operations"`
-	Signatures     []string   `json:"signatures"`
+	RefBlockNum    UInt16                `json:"ref_block_num"`
+	RefBlockPrefix UInt32                `json:"ref_block_prefix"`
+	Expiration     *Time                 `json:"expiration"`
+	Operations     Operations            `json:"operations"`
+	Extensions     TransactionExtensions `json:"extensions"`
+	Signatures     []string              `json:"signatures"`
 }
 
 // MarshalTransaction implements transaction.Marshaller interface.
@@ -34,13 +40,117 @@ func (tx *Transaction) MarshalTransaction(encoder *transaction.Encoder) error {
 		enc.Encode(op)
 	}
 
-	// Extensions are not supported yet.
-	enc.EncodeUVarint(0)
+	enc.Encode(tx.Extensions)
+
+	return enc.Err()
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller interface.
+// The operations are reconstructed by their operation codes.
+func (tx *Transaction) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	tx.Expiration = &Time{}
 
+	dec := transaction.NewRollingDecoder(decoder)
+	dec.Decode(&tx.RefBlockNum)
+	dec.Decode(&tx.RefBlockPrefix)
+	dec.Decode(tx.Expiration)
+
+	var length uint64
+	dec.DecodeUVarint(&length)
+	if err := dec.Err(); err != nil {
+		return err
+	}
+
+	tx.Operations = nil
+	for i := uint64(0); i < length; i++ {
+		op, err := DecodeOperation(decoder)
+		if err != nil {
+			return errors.Wrapf(err, "operation %v", i)
+		}
+		tx.Operations = append(tx.Operations, op)
+	}
+
+	return tx.Extensions.UnmarshalTransaction(decoder)
+}
+
+// signatureLength is the length of a compact signature, recovery byte included.
+const signatureLength = 65
+
+// MarshalSignedTransaction serializes the transaction including the signatures,
+// which is the form returned by get_transaction_hex and used in blocks.
+func (tx *Transaction) MarshalSignedTransaction(encoder *transaction.Encoder) error {
+	if err := tx.MarshalTransaction(encoder); err != nil {
+		return err
+	}
+
+	enc := transaction.NewRollingEncoder(encoder)
+	enc.EncodeUVarint(uint64(len(tx.Signatures)))
+	for _, signature := range tx.Signatures {
+		raw, err := hex.DecodeString(signature)
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode signature: %v", signature)
+		}
+		enc.EncodeRaw(raw)
+	}
 	return enc.Err()
 }
 
+// UnmarshalSignedTransaction is the decoding counterpart of MarshalSignedTransaction.
+func (tx *Transaction) UnmarshalSignedTransaction(decoder *transaction.Decoder) error {
+	if err := tx.UnmarshalTransaction(decoder); err != nil {
+		return err
+	}
+
+	length, err := decoder.DecodeUVarint()
+	if err != nil {
+		return errors.Wrap(err, "failed to decode signatures")
+	}
+
+	tx.Signatures = nil
+	for i := uint64(0); i < length; i++ {
+		signature := make([]byte, signatureLength)
+		if err := decoder.DecodeRaw(signature); err != nil {
+			return errors.Wrapf(err, "failed to decode signature %v", i)
+		}
+		tx.Signatures = append(tx.Signatures, hex.EncodeToString(signature))
+	}
+	return nil
+}
+
+// DecodeTransactionHex decodes a signed transaction serialized as hex,
+// e.g. as returned by get_transaction_hex. All the data must be consumed.
+// The options are passed into the decoder, e.g. transaction.SetCatalog.
+func DecodeTransactionHex(data string, options ...transaction.Option) (*Transaction, error) {
+	raw, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode transaction hex")
+	}
+
+	r := bytes.NewReader(raw)
+	var tx Transaction
+	if err := tx.UnmarshalSignedTransaction(transaction.NewDecoder(r, options...)); err != nil {
+		return nil, err
+	}
+	if r.Len() != 0 {
+		return nil, errors.Errorf("%v bytes left over after the transaction", r.Len())
+	}
+	return &tx, nil
+}
+
 // PushOperation can be used to add an operation into the transaction.
 func (tx *Transaction) PushOperation(op Operation) {
 	tx.Operations = append(tx.Operations, op)
 }
+
+// ID computes the transaction ID, i.e. the first 20 bytes of sha256
+// of the serialized transaction, signatures not included.
+// The options are passed into the encoder, e.g. transaction.SetCatalog.
+func (tx *Transaction) ID(options ...transaction.Option) (string, error) {
+	var b bytes.Buffer
+	if err := tx.MarshalTransaction(transaction.NewEncoder(&b, options...)); err != nil {
+		return "", errors.Wrap(err, "failed to serialize transaction")
+	}
+
+	digest := sha256.Sum256(b.Bytes())
+	return hex.EncodeToString(digest[:20]), nil
+}
@@ -4,6 +4,9 @@ import (
 	// Stdlib
 	"bytes"
 	"encoding/hex"
+	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,3 +46,109 @@ func TestTransaction_MarshalTransaction(t *testing.T) {
 		t.Errorf("expected %v, got %v", expected, got)
 	}
 }
+
+func TestTransaction_ID(t *testing.T) {
+	// sha256 of the serialized transaction from TestTransaction_MarshalTransaction.
+	expected := "12164dcee518674c586e6a61d08623c44980e326"
+
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	tx := Transaction{
+		RefBlockNum:    36029,
+		RefBlockPrefix: 1164960351,
+		Expiration:     &Time{&expiration},
+		Signatures:     []string{"signatures are not part of the ID"},
+	}
+	tx.PushOperation(&VoteOperation{
+		Voter:    "xeroc",
+		Author:   "xeroc",
+		Permlink: "piston",
+		Weight:   10000,
+	})
+
+	got, err := tx.ID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != expected {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDecodeTransactionHex(t *testing.T) {
+	signature := "1f" + strings.Repeat("11", 64)
+
+	// The transaction from TestTransaction_MarshalTransaction with a single signature.
+	input := "bd8c5fe26f45f179a8570100057865726f63057865726f6306706973746f6e102700" + "01" + signature
+
+	tx, err := DecodeTransactionHex(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiration := time.Date(2016, 8, 8, 12, 24, 17, 0, time.UTC)
+	if tx.RefBlockNum != 36029 || tx.RefBlockPrefix != 1164960351 || !tx.Expiration.Equal(expiration) {
+		t.Errorf("unexpected transaction header: %+v", tx)
+	}
+	if len(tx.Operations) != 1 {
+		t.Fatalf("expected a single operation, got %v", len(tx.Operations))
+	}
+	if vote, ok := tx.Operations[0].(*VoteOperation); !ok || vote.Voter != "xeroc" || vote.Weight != 10000 {
+		t.Errorf("unexpected operation: %+v", tx.Operations[0])
+	}
+	if len(tx.Signatures) != 1 || tx.Signatures[0] != signature {
+		t.Errorf("unexpected signatures: %v", tx.Signatures)
+	}
+
+	var b bytes.Buffer
+	if err := tx.MarshalSignedTransaction(transaction.NewEncoder(&b)); err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(b.Bytes()); got != input {
+		t.Errorf("round trip: expected %v, got %v", input, got)
+	}
+
+	for _, invalid := range []string{input + "00", input[:len(input)-2], "zz"} {
+		if _, err := DecodeTransactionHex(invalid); err == nil {
+			t.Errorf("%v: expected an error", invalid)
+		}
+	}
+}
+
+func TestTransaction_MarshalJSON_HTML(t *testing.T) {
+	input := `{"ref_block_num":36029,"ref_block_prefix":1164960351,"expiration":"2016-08-08T12:24:17",` +
+		`"operations":[` +
+		`["custom_json",{"required_auths":[],"required_posting_auths":["alice"],"id":"app","json":"{\"html\":\"<b>&</b>\"}"}],` +
+		`["future_operation",{"html":"<b>&</b>"}]` +
+		`],"extensions":[],"signatures":[]}`
+
+	var tx Transaction
+	if err := json.Unmarshal([]byte(input), &tx); err != nil {
+		t.Fatal(err)
+	}
+
+	// JSONMarshal keeps the HTML characters in the nested operations.
+	data, err := JSONMarshal(&tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(bytes.TrimSpace(data)); got != input {
+		t.Errorf("expected %v, got %v", input, got)
+	}
+
+	// json.Marshal escapes HTML characters, the result is equivalent though.
+	data, err = json.Marshal(&tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "<") || !strings.Contains(string(data), `\u003cb\u003e\u0026`) {
+		t.Errorf("expected escaped HTML characters, got %v", string(data))
+	}
+	var roundTrip Transaction
+	if err := json.Unmarshal(data, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roundTrip.Operations[0], tx.Operations[0]) ||
+		string(roundTrip.Operations[1].(*UnknownOperation).RawJSON()) != `{"html":"\u003cb\u003e\u0026\u003c/b\u003e"}` {
+		t.Errorf("unexpected operations: %v", roundTrip.Operations)
+	}
+}
@@ -63,6 +63,15 @@ func (num UInt8) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint8(num))
 }
 
+func (num *UInt8) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint8
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt8(v)
+	return nil
+}
+
 type UInt16 uint16
 
 func (num *UInt16) UnmarshalJSON(data []byte) error {
@@ -79,6 +88,15 @@ func (num UInt16) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint16(num))
 }
 
+func (num *UInt16) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint16
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt16(v)
+	return nil
+}
+
 type UInt32 uint32
 
 func (num *UInt32) UnmarshalJSON(data []byte) error {
@@ -95,6 +113,15 @@ func (num UInt32) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint32(num))
 }
 
+func (num *UInt32) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt32(v)
+	return nil
+}
+
 type UInt64 uint64
 
 func (num *UInt64) UnmarshalJSON(data []byte) error {
@@ -110,3 +137,12 @@ func (num *UInt64) UnmarshalJSON(data []byte) error {
 func (num UInt64) MarshalTransaction(encoder *transaction.Encoder) error {
 	return encoder.EncodeNumber(uint64(num))
 }
+
+func (num *UInt64) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint64
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return err
+	}
+	*num = UInt64(v)
+	return nil
+}
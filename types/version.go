@@ -0,0 +1,85 @@
+package types
+
+import (
+	// Stdlib
+	"encoding/json"
+	"fmt"
+
+	// RPC
+	"github.com/goscorum/scorumgo/encoding/transaction"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+// Version is the version of the node software, e.g. 0.19.2.
+//
+// In JSON it is the dotted string, in the binary form it is a single uint32
+// with the major version in the top byte, the hardfork in the next one
+// and the revision in the bottom 16 bits.
+type Version struct {
+	Major    uint8
+	Hardfork uint8
+	Revision uint16
+}
+
+// ParseVersion parses a version string such as "0.19.2".
+func ParseVersion(s string) (*Version, error) {
+	var (
+		version Version
+		rest    string
+	)
+	n, _ := fmt.Sscanf(s, "%d.%d.%d%s", &version.Major, &version.Hardfork, &version.Revision, &rest)
+	if n != 3 || version.String() != s {
+		return nil, errors.Errorf("invalid version: %q", s)
+	}
+	return &version, nil
+}
+
+// String returns the version as major.hardfork.revision.
+func (version *Version) String() string {
+	return fmt.Sprintf("%v.%v.%v", version.Major, version.Hardfork, version.Revision)
+}
+
+func (version *Version) number() uint32 {
+	return uint32(version.Major)<<24 | uint32(version.Hardfork)<<16 | uint32(version.Revision)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (version *Version) MarshalJSON() ([]byte, error) {
+	return json.Marshal(version.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (version *Version) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal version: %v", string(data))
+	}
+	parsed, err := ParseVersion(s)
+	if err != nil {
+		return err
+	}
+	*version = *parsed
+	return nil
+}
+
+// MarshalTransaction implements transaction.TransactionMarshaller.
+func (version *Version) MarshalTransaction(encoder *transaction.Encoder) error {
+	if version == nil {
+		return errors.New("version not set")
+	}
+	return encoder.EncodeNumber(version.number())
+}
+
+// UnmarshalTransaction implements transaction.TransactionUnmarshaller.
+func (version *Version) UnmarshalTransaction(decoder *transaction.Decoder) error {
+	var v uint32
+	if err := decoder.DecodeNumber(&v); err != nil {
+		return errors.Wrap(err, "failed to decode version")
+	}
+	version.Major = uint8(v >> 24)
+	version.Hardfork = uint8(v >> 16)
+	version.Revision = uint16(v)
+	return nil
+}
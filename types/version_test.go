@@ -0,0 +1,22 @@
+package types
+
+import (
+	// Stdlib
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	version, err := ParseVersion("0.19.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *version != (Version{Major: 0, Hardfork: 19, Revision: 2}) || version.number() != 0x00130002 {
+		t.Errorf("unexpected version: %+v", version)
+	}
+
+	for _, input := range []string{"", "0.19", "0.19.2.1", "0.19.x", "0.256.0", "00.19.2", " 0.19.2"} {
+		if _, err := ParseVersion(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package witness
+
+import (
+	// Stdlib
+	"fmt"
+	"time"
+)
+
+// AlertHandler processes alerts emitted by Monitor,
+// i.e. *NearThreshold, *ThresholdCrossed and *Recovered.
+//
+// Returning an error stops the monitor, the error is then returned from Run.
+type AlertHandler interface {
+	HandleAlert(alert interface{}) error
+}
+
+// AlertHandlerFunc is an adapter allowing to use an ordinary function as an AlertHandler.
+type AlertHandlerFunc func(alert interface{}) error
+
+// HandleAlert implements AlertHandler.
+func (f AlertHandlerFunc) HandleAlert(alert interface{}) error {
+	return f(alert)
+}
+
+// NearThreshold is emitted when the number of slots missed by the witness
+// within the window reaches the warning level.
+type NearThreshold struct {
+	Witness   string
+	Missed    int
+	Threshold int
+	Time      time.Time
+}
+
+func (e *NearThreshold) String() string {
+	return fmt.Sprintf("NEAR THRESHOLD [witness=%v, missed=%v, threshold=%v, time=%v]",
+		e.Witness, e.Missed, e.Threshold, e.Time)
+}
+
+// ThresholdCrossed is emitted when the number of slots missed by the witness
+// within the window reaches the threshold.
+type ThresholdCrossed struct {
+	Witness   string
+	Missed    int
+	Threshold int
+	Time      time.Time
+}
+
+func (e *ThresholdCrossed) String() string {
+	return fmt.Sprintf("THRESHOLD CROSSED [witness=%v, missed=%v, threshold=%v, time=%v]",
+		e.Witness, e.Missed, e.Threshold, e.Time)
+}
+
+// Recovered is emitted when the number of slots missed by the witness
+// within the window drops below the warning level again
+// after NearThreshold or ThresholdCrossed was emitted.
+type Recovered struct {
+	Witness   string
+	Missed    int
+	Threshold int
+	Time      time.Time
+}
+
+func (e *Recovered) String() string {
+	return fmt.Sprintf("RECOVERED [witness=%v, missed=%v, threshold=%v, time=%v]",
+		e.Witness, e.Missed, e.Threshold, e.Time)
+}
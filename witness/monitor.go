@@ -0,0 +1,305 @@
+// Package witness watches block production and alerts on witnesses missing blocks.
+package witness
+
+import (
+	// Stdlib
+	"context"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/stream"
+
+	// Vendor
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultWindow is the default number of most recent slots
+	// the missed slots are counted in, i.e. one day of 3 second slots.
+	DefaultWindow = 28800
+
+	// DefaultWarningPercent is the default warning level
+	// as a percentage of the threshold.
+	DefaultWarningPercent = 75
+)
+
+// API is the subset of database_api the monitor is using.
+// It is implemented by *database.API.
+type API interface {
+	stream.BlockAPI
+	GetWitnessSchedule() (*database.WitnessSchedule, error)
+}
+
+// Monitor follows the head block and counts the slots missed by every witness
+// within a rolling window of the most recent slots.
+//
+// A slot is missed when the witness scheduled to produce it does not produce
+// a block in time, i.e. when the next block timestamp skips the slot.
+// Alerts are emitted when the number of missed slots reaches the warning level,
+// reaches the threshold and when it drops below the warning level again.
+type Monitor struct {
+	api API
+
+	// Options.
+	window         uint64
+	threshold      int
+	warningPercent int
+	historySize    int
+	streamOptions  []stream.Option
+}
+
+// Option represents an option that can be passed into the monitor constructor.
+type Option func(*Monitor)
+
+// SetWindow sets the number of most recent slots the missed slots are counted in.
+//
+// The default value is DefaultWindow.
+func SetWindow(slots uint64) Option {
+	return func(m *Monitor) {
+		m.window = slots
+	}
+}
+
+// SetThreshold sets the number of missed slots ThresholdCrossed is emitted at.
+//
+// The default value is SCORUM_WITNESS_MISSED_BLOCKS_THRESHOLD as returned by get_config.
+func SetThreshold(threshold int) Option {
+	return func(m *Monitor) {
+		m.threshold = threshold
+	}
+}
+
+// SetWarningPercent sets the number of missed slots NearThreshold is emitted at
+// as a percentage of the threshold.
+//
+// The default value is DefaultWarningPercent.
+func SetWarningPercent(percent int) Option {
+	return func(m *Monitor) {
+		m.warningPercent = percent
+	}
+}
+
+// SetHistorySize sets how many recent blocks the monitor is able to revert
+// when the head block is replaced by a fork.
+//
+// The default value is stream.DefaultHistorySize.
+func SetHistorySize(size int) Option {
+	return func(m *Monitor) {
+		m.historySize = size
+	}
+}
+
+// SetStreamOptions sets the options passed into the underlying head follower,
+// e.g. stream.SetRetryDelay or stream.SetMonitor. The retry options
+// apply to the initial RPC calls made by the monitor as well.
+// The start block and the history size are always set by the monitor.
+func SetStreamOptions(options ...stream.Option) Option {
+	return func(m *Monitor) {
+		m.streamOptions = options
+	}
+}
+
+// NewMonitor creates a new monitor using the given API.
+func NewMonitor(api API, options ...Option) *Monitor {
+	m := &Monitor{
+		api:            api,
+		window:         DefaultWindow,
+		warningPercent: DefaultWarningPercent,
+		historySize:    stream.DefaultHistorySize,
+	}
+	for _, opt := range options {
+		opt(m)
+	}
+	return m
+}
+
+// Run keeps following the head block and emitting alerts
+// until the context is cancelled or the handler fails.
+//
+// The monitor starts with the current head block and it relies
+// on the current witness schedule, so it is not able to process past blocks.
+// Blocks replaced by a fork are reverted, the slots they were counted for
+// are counted again for the blocks of the new fork.
+func (m *Monitor) Run(ctx context.Context, handler AlertHandler) error {
+	// The streamer is only used to retry the calls the same way the follower does.
+	retrier := stream.NewStreamer(m.api, m.streamOptions...)
+
+	var config *database.Config
+	err := retrier.Retry(ctx, "get_config", func() (err error) {
+		config, err = m.api.GetConfig()
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	var props *database.DynamicGlobalProperties
+	err = retrier.Retry(ctx, "get_dynamic_global_properties", func() (err error) {
+		props, err = m.api.GetDynamicGlobalProperties()
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	getSchedule := func() (schedule *database.WitnessSchedule, err error) {
+		err = retrier.Retry(ctx, "get_witness_schedule", func() (err error) {
+			schedule, err = m.api.GetWitnessSchedule()
+			return
+		})
+		return
+	}
+
+	schedule, err := getSchedule()
+	if err != nil {
+		return err
+	}
+
+	production, err := database.NewProductionSchedule(schedule, props, config)
+	if err != nil {
+		return err
+	}
+
+	threshold := m.threshold
+	if threshold == 0 {
+		threshold = int(config.ScorumWitnessMissedBlocksThreshold)
+	}
+	if threshold < 1 {
+		return errors.Errorf("witness: invalid missed blocks threshold: %v", threshold)
+	}
+	warning := threshold * m.warningPercent / 100
+	if warning < 1 {
+		warning = 1
+	}
+
+	state := &monitorState{
+		getSchedule: getSchedule,
+		production:  production,
+		aslot:       production.HeadAslot,
+		lastTime:    production.HeadTime,
+		tracker:     newTracker(m.window, threshold, warning),
+		handler:     handler,
+		historySize: m.historySize,
+	}
+
+	options := make([]stream.FollowerOption, 0, len(m.streamOptions)+2)
+	for _, opt := range m.streamOptions {
+		options = append(options, opt)
+	}
+	options = append(options,
+		stream.SetStartBlock(production.HeadBlockNum+1),
+		stream.SetHistorySize(m.historySize))
+
+	follower := stream.NewHeadFollower(m.api, options...)
+	return follower.Run(ctx, stream.EventHandlerFunc(state.handleEvent))
+}
+
+// monitorState is the state of a single Monitor.Run call.
+type monitorState struct {
+	getSchedule func() (*database.WitnessSchedule, error)
+	production  *database.ProductionSchedule
+	aslot       uint64
+	lastTime    time.Time
+	tracker     *tracker
+	handler     AlertHandler
+
+	// history keeps the state preceding every recently applied block
+	// so that the block can be reverted.
+	historySize int
+	history     []*monitorSnapshot
+}
+
+type monitorSnapshot struct {
+	blockNum            uint32
+	aslot               uint64
+	lastTime            time.Time
+	witnesses           []string
+	nextShuffleBlockNum uint32
+	missed              map[string][]uint64
+}
+
+func (state *monitorState) handleEvent(event interface{}) error {
+	switch event := event.(type) {
+	case *stream.Applied:
+		return state.applyBlock(event.Block)
+	case *stream.Reverted:
+		return state.revertBlock(event.BlockNum)
+	}
+	return nil
+}
+
+func (state *monitorState) applyBlock(block *database.Block) error {
+	state.history = append(state.history, &monitorSnapshot{
+		blockNum:            block.Number,
+		aslot:               state.aslot,
+		lastTime:            state.lastTime,
+		witnesses:           state.production.Witnesses,
+		nextShuffleBlockNum: state.production.NextShuffleBlockNum,
+		missed:              state.tracker.snapshot(),
+	})
+	if len(state.history) > state.historySize {
+		state.history = state.history[len(state.history)-state.historySize:]
+	}
+
+	return state.handleHeader(block.Header())
+}
+
+func (state *monitorState) revertBlock(blockNum uint32) error {
+	n := len(state.history)
+	if n == 0 || state.history[n-1].blockNum != blockNum {
+		return errors.Errorf("witness: block %v cannot be reverted", blockNum)
+	}
+	snapshot := state.history[n-1]
+	state.history = state.history[:n-1]
+
+	state.aslot = snapshot.aslot
+	state.lastTime = snapshot.lastTime
+	state.production.Witnesses = snapshot.witnesses
+	state.production.NextShuffleBlockNum = snapshot.nextShuffleBlockNum
+	state.tracker.restore(snapshot.missed)
+	return nil
+}
+
+func (state *monitorState) handleHeader(header *database.BlockHeader) error {
+	if header.Timestamp == nil || header.Timestamp.Time == nil {
+		return errors.Errorf("witness: block %v: timestamp not set", header.Number)
+	}
+	timestamp := *header.Timestamp.Time
+
+	// The witnesses are shuffled once the shuffle block is applied.
+	if header.Number > state.production.NextShuffleBlockNum {
+		schedule, err := state.getSchedule()
+		if err != nil {
+			return err
+		}
+		witnesses := schedule.Witnesses()
+		if len(witnesses) == 0 {
+			return errors.New("witness: no witnesses scheduled")
+		}
+		state.production.Witnesses = witnesses
+		state.production.NextShuffleBlockNum = schedule.NextShuffleBlockNum
+	}
+
+	slots := uint64(timestamp.Sub(state.lastTime) / state.production.Interval)
+	if slots == 0 {
+		return errors.Errorf("witness: block %v: timestamp %v not after the previous block",
+			header.Number, timestamp)
+	}
+
+	// Every slot skipped since the previous block was missed.
+	witnesses := state.production.Witnesses
+	for i := uint64(1); i < slots; i++ {
+		aslot := state.aslot + i
+		state.tracker.miss(witnesses[aslot%uint64(len(witnesses))], aslot)
+	}
+	state.aslot += slots
+	state.lastTime = timestamp
+
+	for _, alert := range state.tracker.advance(state.aslot, timestamp) {
+		if err := state.handler.HandleAlert(alert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
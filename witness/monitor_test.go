@@ -0,0 +1,156 @@
+package witness
+
+import (
+	// Stdlib
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/stream"
+	"github.com/goscorum/scorumgo/types"
+)
+
+var monitorHeadTime = time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// forkingAPI serves a chain with the head block 100 in slot 300. Once the monitor
+// has started, blocks 101-103 are produced in slots 301, 303 and 304,
+// i.e. carol misses slot 302.
+// Once block 103 is fetched, blocks 102 and up are replaced by a fork
+// producing them in slots 302-304, so no slot is missed in the end.
+//
+// The first call to get_witness_schedule fails to check it is retried.
+type forkingAPI struct {
+	mu             sync.Mutex
+	head           uint32
+	forked         bool
+	scheduleCalled bool
+}
+
+func (api *forkingAPI) GetConfig() (*database.Config, error) {
+	return &database.Config{ScorumBlockInterval: 3}, nil
+}
+
+func (api *forkingAPI) GetDynamicGlobalProperties() (*database.DynamicGlobalProperties, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	// Only the first call made by the monitor needs the time and the slot.
+	props := &database.DynamicGlobalProperties{
+		Time:            types.Time{Time: &monitorHeadTime},
+		HeadBlockNumber: types.UInt32(api.head),
+		CurrentAslot:    300,
+	}
+	if !api.forked {
+		api.head = 103
+	}
+	return props, nil
+}
+
+func (api *forkingAPI) GetWitnessSchedule() (*database.WitnessSchedule, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if !api.scheduleCalled {
+		api.scheduleCalled = true
+		return nil, errors.New("connection reset")
+	}
+	return &database.WitnessSchedule{
+		CurrentShuffledWitnesses: []string{"alice", "bob", "carol"},
+		NextShuffleBlockNum:      1000,
+	}, nil
+}
+
+func (api *forkingAPI) GetBlock(blockNum uint32) (*database.Block, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	id := func(num uint32) string {
+		if api.forked && num >= 102 {
+			return fmt.Sprintf("b%v", num)
+		}
+		return fmt.Sprintf("a%v", num)
+	}
+
+	slot := blockNum - 100
+	if !api.forked && blockNum >= 102 {
+		slot++
+	}
+
+	block := &database.Block{
+		Number:   blockNum,
+		BlockID:  id(blockNum),
+		Previous: id(blockNum - 1),
+	}
+	if blockNum <= api.head {
+		timestamp := monitorHeadTime.Add(time.Duration(slot) * 3 * time.Second)
+		block.Timestamp = &types.Time{Time: &timestamp}
+	}
+
+	if blockNum == 103 && !api.forked {
+		api.forked = true
+		api.head = 104
+	}
+	return block, nil
+}
+
+func (api *forkingAPI) GetBlockHeader(blockNum uint32) (*database.BlockHeader, error) {
+	block, err := api.GetBlock(blockNum)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+func (api *forkingAPI) GetOpsInBlock(blockNum uint32, onlyVirtual bool) ([]*database.AppliedOperation, error) {
+	return nil, nil
+}
+
+func TestMonitor_Run(t *testing.T) {
+	api := &forkingAPI{head: 100}
+
+	retries := make(chan interface{}, 10)
+	monitor := NewMonitor(api,
+		SetWindow(100),
+		SetThreshold(2),
+		SetWarningPercent(50),
+		SetStreamOptions(
+			stream.SetPollInterval(time.Millisecond),
+			stream.SetRetryDelay(time.Millisecond),
+			stream.SetMonitor(retries)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var alerts []interface{}
+	err := monitor.Run(ctx, AlertHandlerFunc(func(alert interface{}) error {
+		alerts = append(alerts, alert)
+		if _, ok := alert.(*Recovered); ok {
+			cancel()
+		}
+		return nil
+	}))
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if len(retries) != 1 {
+		t.Errorf("expected a single retry, got %v", len(retries))
+	}
+
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %v", alertsString(alerts))
+	}
+	near, ok := alerts[0].(*NearThreshold)
+	if !ok || near.Witness != "carol" || near.Missed != 1 {
+		t.Errorf("expected carol to be near the threshold, got %v", alertsString(alerts))
+	}
+	recovered, ok := alerts[1].(*Recovered)
+	if !ok || recovered.Witness != "carol" || recovered.Missed != 0 {
+		t.Errorf("expected carol to recover once the fork is applied, got %v", alertsString(alerts))
+	}
+}
@@ -0,0 +1,114 @@
+package witness
+
+import (
+	// Stdlib
+	"sort"
+	"time"
+)
+
+type alertLevel int
+
+const (
+	levelNone alertLevel = iota
+	levelNear
+	levelCrossed
+)
+
+// tracker counts missed slots per witness within a rolling window of slots.
+// Slots are identified by the absolute slot number, i.e. current_aslot.
+type tracker struct {
+	window    uint64
+	threshold int
+	warning   int
+
+	missed map[string][]uint64
+	levels map[string]alertLevel
+}
+
+func newTracker(window uint64, threshold, warning int) *tracker {
+	return &tracker{
+		window:    window,
+		threshold: threshold,
+		warning:   warning,
+		missed:    make(map[string][]uint64),
+		levels:    make(map[string]alertLevel),
+	}
+}
+
+// miss records the given slot as missed by the witness.
+func (t *tracker) miss(witness string, aslot uint64) {
+	t.missed[witness] = append(t.missed[witness], aslot)
+}
+
+// snapshot returns a copy of the missed slots to be passed into restore.
+func (t *tracker) snapshot() map[string][]uint64 {
+	missed := make(map[string][]uint64, len(t.missed))
+	for witness, slots := range t.missed {
+		missed[witness] = append([]uint64(nil), slots...)
+	}
+	return missed
+}
+
+// restore replaces the missed slots with a snapshot.
+//
+// The alert levels are kept, they reflect the alerts emitted already,
+// so the next call to advance emits Recovered in case the level dropped.
+func (t *tracker) restore(missed map[string][]uint64) {
+	t.missed = missed
+}
+
+// advance moves the window so that it ends with the given slot
+// and returns the alerts for the witnesses that changed their alert level.
+func (t *tracker) advance(aslot uint64, now time.Time) []interface{} {
+	// Witnesses with no slots missed may still need to recover
+	// when their slots were dropped by restore.
+	witnesses := make([]string, 0, len(t.missed))
+	for witness := range t.missed {
+		witnesses = append(witnesses, witness)
+	}
+	for witness := range t.levels {
+		if _, ok := t.missed[witness]; !ok {
+			witnesses = append(witnesses, witness)
+		}
+	}
+	// Keep the alerts deterministic.
+	sort.Strings(witnesses)
+
+	var alerts []interface{}
+	for _, witness := range witnesses {
+		slots := t.missed[witness]
+		for len(slots) != 0 && slots[0]+t.window <= aslot {
+			slots = slots[1:]
+		}
+		if len(slots) == 0 {
+			delete(t.missed, witness)
+		} else {
+			t.missed[witness] = slots
+		}
+
+		if alert := t.update(witness, len(slots), now); alert != nil {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// update sets the alert level of the witness and returns the alert to be emitted, if any.
+func (t *tracker) update(witness string, missed int, now time.Time) interface{} {
+	level := t.levels[witness]
+
+	switch {
+	case missed >= t.threshold && level < levelCrossed:
+		t.levels[witness] = levelCrossed
+		return &ThresholdCrossed{Witness: witness, Missed: missed, Threshold: t.threshold, Time: now}
+
+	case missed >= t.warning && level == levelNone:
+		t.levels[witness] = levelNear
+		return &NearThreshold{Witness: witness, Missed: missed, Threshold: t.threshold, Time: now}
+
+	case missed < t.warning && level != levelNone:
+		delete(t.levels, witness)
+		return &Recovered{Witness: witness, Missed: missed, Threshold: t.threshold, Time: now}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package witness
+
+import (
+	// Stdlib
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	// RPC
+	"github.com/goscorum/scorumgo/apis/database"
+	"github.com/goscorum/scorumgo/types"
+)
+
+func alertsString(alerts []interface{}) string {
+	var ss []string
+	for _, alert := range alerts {
+		ss = append(ss, fmt.Sprint(alert))
+	}
+	return strings.Join(ss, "; ")
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	tr := newTracker(10, 3, 2)
+
+	expect := func(aslot uint64, expected ...string) {
+		t.Helper()
+		var kinds []string
+		for _, alert := range tr.advance(aslot, now) {
+			switch alert := alert.(type) {
+			case *NearThreshold:
+				kinds = append(kinds, fmt.Sprintf("near %v %v", alert.Witness, alert.Missed))
+			case *ThresholdCrossed:
+				kinds = append(kinds, fmt.Sprintf("crossed %v %v", alert.Witness, alert.Missed))
+			case *Recovered:
+				kinds = append(kinds, fmt.Sprintf("recovered %v %v", alert.Witness, alert.Missed))
+			}
+		}
+		if strings.Join(kinds, ", ") != strings.Join(expected, ", ") {
+			t.Errorf("slot %v: expected %v, got %v", aslot, expected, kinds)
+		}
+	}
+
+	tr.miss("alice", 1)
+	expect(2)
+
+	tr.miss("alice", 3)
+	tr.miss("bob", 3)
+	expect(4, "near alice 2")
+
+	tr.miss("alice", 5)
+	expect(6, "crossed alice 3")
+
+	tr.miss("alice", 7)
+	expect(8)
+
+	// Slot 1 leaves the window, alice is still over the threshold.
+	expect(11)
+
+	// Slots 3 and 5 leave the window.
+	expect(15, "recovered alice 1")
+
+	// Bob's slot 3 has left the window already.
+	tr.miss("bob", 15)
+	tr.miss("bob", 16)
+	expect(17, "near bob 2")
+
+	// Everything leaves the window.
+	expect(30, "recovered bob 0")
+	if len(tr.missed) != 0 || len(tr.levels) != 0 {
+		t.Errorf("expected the tracker to be empty, got %v, %v", tr.missed, tr.levels)
+	}
+}
+
+func TestMonitorState_HandleHeader(t *testing.T) {
+	headTime := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var alerts []interface{}
+	state := &monitorState{
+		production: &database.ProductionSchedule{
+			Witnesses:           []string{"alice", "bob", "carol"},
+			Interval:            3 * time.Second,
+			HeadBlockNum:        100,
+			NextShuffleBlockNum: 1000,
+		},
+		aslot:    300,
+		lastTime: headTime,
+		tracker:  newTracker(100, 2, 1),
+		handler: AlertHandlerFunc(func(alert interface{}) error {
+			alerts = append(alerts, alert)
+			return nil
+		}),
+	}
+
+	header := func(num uint32, slots int) *database.BlockHeader {
+		timestamp := headTime.Add(time.Duration(slots) * 3 * time.Second)
+		return &database.BlockHeader{Number: num, Timestamp: &types.Time{Time: &timestamp}}
+	}
+
+	// Slot 301 (bob) is produced, slot 302 (carol) is missed, slot 303 (alice) is produced.
+	for _, h := range []*database.BlockHeader{header(101, 1), header(102, 3)} {
+		if err := state.handleHeader(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(alerts) != 1 {
+		t.Fatalf("expected a single alert, got %v", alertsString(alerts))
+	}
+	alert, ok := alerts[0].(*NearThreshold)
+	if !ok || alert.Witness != "carol" || alert.Missed != 1 {
+		t.Errorf("expected carol to be near the threshold, got %v", alertsString(alerts))
+	}
+	if state.aslot != 303 {
+		t.Errorf("expected slot 303, got %v", state.aslot)
+	}
+}